@@ -0,0 +1,253 @@
+package tripit
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Codec encodes requests to and decodes responses from one of the TripIt wire formats.
+type Codec interface {
+	Format() string                          // format name used in URLs and as the form field name, for example "json"
+	Encode(v interface{}) ([]byte, error)    // encodes a Request
+	Decode(r io.Reader, v interface{}) error // decodes a Response
+}
+
+// Codecs for the formats supported by TripIt
+var (
+	JsonCodec Codec = jsonCodec{}
+	XmlCodec  Codec = xmlCodec{}
+
+	// JsonSingleObjectCodec is the JSON format with lists of one element encoded as a
+	// single object rather than an array of one, matching what TripIt sends.
+	JsonSingleObjectCodec Codec = jsonCodec{singleObject: true}
+)
+
+// jsonCodec implements the JSON wire format.
+type jsonCodec struct {
+	singleObject bool // encode a OneOrMany with one element as an object
+}
+
+// Format returns "json".
+func (jsonCodec) Format() string {
+	return "json"
+}
+
+// Encode encodes v as JSON.
+func (c jsonCodec) Encode(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || !c.singleObject {
+		return b, err
+	}
+	return singleObjects(b, reflect.TypeOf(v))
+}
+
+// Decode decodes JSON from r into v. A struct such as a Response is streamed: lists of
+// objects are decoded one object at a time, so memory grows with the largest object
+// rather than with the whole response.
+func (jsonCodec) Decode(r io.Reader, v interface{}) error {
+	return decodeJSON(r, v)
+}
+
+// oneOrMany is implemented by all OneOrMany types.
+type oneOrMany interface {
+	isOneOrMany()
+}
+
+var (
+	oneOrManyType = reflect.TypeOf((*oneOrMany)(nil)).Elem()
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textType      = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// singleObjects rewrites the JSON encoding b of a value of type t so that every OneOrMany
+// with one element is encoded as that element. The order of object keys is kept.
+func singleObjects(b []byte, t reflect.Type) ([]byte, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || bytes.Equal(b, []byte("null")) {
+		return b, nil
+	}
+	switch {
+	case t.Implements(oneOrManyType):
+		var elems []json.RawMessage
+		if err := json.Unmarshal(b, &elems); err != nil {
+			return nil, err
+		}
+		if len(elems) == 1 {
+			return singleObjects(elems[0], t.Elem())
+		}
+		return singleObjectsArray(elems, t.Elem())
+	case implements(t, marshalerType), implements(t, textType):
+		return b, nil
+	case t.Kind() == reflect.Struct:
+		return singleObjectsStruct(b, jsonFields(t))
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8, t.Kind() == reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(b, &elems); err != nil {
+			return nil, err
+		}
+		return singleObjectsArray(elems, t.Elem())
+	}
+	return b, nil
+}
+
+// implements returns true if t or a pointer to t implements the interface u.
+func implements(t, u reflect.Type) bool {
+	return t.Implements(u) || reflect.PointerTo(t).Implements(u)
+}
+
+// singleObjectsArray rewrites the elements of an array of values of type t.
+func singleObjectsArray(elems []json.RawMessage, t reflect.Type) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, e := range elems {
+		e, err := singleObjects(e, t)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(e)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// singleObjectsStruct rewrites the members of an object encoded from a struct with the
+// given fields.
+func singleObjectsStruct(b []byte, fields map[string]reflect.Type) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return b, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		if t, ok := fields[key]; ok {
+			if v, err = singleObjects(v, t); err != nil {
+				return nil, err
+			}
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonFields returns the types of the exported fields of a struct by JSON name,
+// including the fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// xmlCodec implements the XML wire format.
+type xmlCodec struct{}
+
+// Format returns "xml".
+func (xmlCodec) Format() string {
+	return "xml"
+}
+
+// Encode encodes v as XML. A Request is encoded with a Request root element.
+func (xmlCodec) Encode(v interface{}) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+// Decode decodes XML from r into v.
+func (xmlCodec) Decode(r io.Reader, v interface{}) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+// MarshalXMLAttr encodes the profile reference as the ref attribute of a Profile.
+func (a ProfileAttributes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return stringAttr(name, a.Ref)
+}
+
+// UnmarshalXMLAttr decodes the ref attribute of a Profile.
+func (a *ProfileAttributes) UnmarshalXMLAttr(attr xml.Attr) error {
+	a.Ref = attr.Value
+	return nil
+}
+
+// MarshalXMLAttr encodes the profile reference as the profile_ref attribute of an Invitee.
+func (a InviteeAttributes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return stringAttr(name, a.ProfileRef)
+}
+
+// UnmarshalXMLAttr decodes the profile_ref attribute of an Invitee.
+func (a *InviteeAttributes) UnmarshalXMLAttr(attr xml.Attr) error {
+	a.ProfileRef = attr.Value
+	return nil
+}
+
+// MarshalXMLAttr encodes the profile reference as the profile_ref attribute of a ClosenessMatch.
+func (a ClosenessMatchAttributes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return stringAttr(name, a.ProfileRef)
+}
+
+// UnmarshalXMLAttr decodes the profile_ref attribute of a ClosenessMatch.
+func (a *ClosenessMatchAttributes) UnmarshalXMLAttr(attr xml.Attr) error {
+	a.ProfileRef = attr.Value
+	return nil
+}
+
+// stringAttr returns an attribute with the given name and value. An empty value gives
+// an empty xml.Attr, which the encoder omits.
+func stringAttr(name xml.Name, value string) (xml.Attr, error) {
+	if value == "" {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: value}, nil
+}
@@ -0,0 +1,180 @@
+package tripit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const tripXML = `<?xml version="1.0" encoding="utf-8"?>
+<Response>
+  <timestamp>1306543281</timestamp>
+  <num_bytes>1024</num_bytes>
+  <Trip>
+    <id>1</id>
+    <display_name>Cancun</display_name>
+    <start_date>2011-12-09</start_date>
+    <end_date>2011-12-27</end_date>
+    <is_private>false</is_private>
+    <TripInvitees>
+      <Invitee profile_ref="def">
+        <is_read_only>true</is_read_only>
+        <is_traveler>true</is_traveler>
+      </Invitee>
+    </TripInvitees>
+  </Trip>
+  <AirObject>
+    <id>2</id>
+    <trip_id>1</trip_id>
+    <Segment>
+      <StartDateTime>
+        <date>2011-12-09</date>
+        <time>08:00:00</time>
+        <timezone>America/New_York</timezone>
+      </StartDateTime>
+      <start_airport_code>JFK</start_airport_code>
+      <start_airport_latitude>40.6398</start_airport_latitude>
+      <end_airport_code>CUN</end_airport_code>
+    </Segment>
+    <Segment>
+      <start_airport_code>CUN</start_airport_code>
+      <end_airport_code>JFK</end_airport_code>
+    </Segment>
+  </AirObject>
+  <Profile ref="abc">
+    <screen_name>traveler</screen_name>
+    <is_pro>true</is_pro>
+  </Profile>
+</Response>`
+
+func TestXmlDecode(t *testing.T) {
+	r := new(Response)
+	if err := XmlCodec.Decode(strings.NewReader(tripXML), r); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Trip) != 1 || r.Trip[0].DisplayName != "Cancun" || r.Trip[0].StartDate != (Date{2011, 12, 9}) {
+		t.Fatalf("Unexpected trips: %+v", r.Trip)
+	}
+	inv := r.Trip[0].TripInvitees
+	if inv == nil || len(inv.Invitee) != 1 || inv.Invitee[0].Attributes.ProfileRef != "def" || !inv.Invitee[0].IsTraveler {
+		t.Errorf("Unexpected invitees: %+v", inv)
+	}
+	if len(r.AirObject) != 1 || len(r.AirObject[0].Segment) != 2 {
+		t.Fatalf("Unexpected air objects: %+v", r.AirObject)
+	}
+	s := r.AirObject[0].Segment[0]
+	if s.StartAirportCode != "JFK" || s.StartAirportLatitude != 40.6398 || s.StartDateTime == nil || s.StartDateTime.Timezone != "America/New_York" {
+		t.Errorf("Unexpected segment: %+v", s)
+	}
+	if len(r.Profile) != 1 || r.Profile[0].Attributes.Ref != "abc" || !r.Profile[0].IsPro {
+		t.Errorf("Unexpected profiles: %+v", r.Profile)
+	}
+	if r.NumBytes != 1024 {
+		t.Errorf("Expected 1024 bytes, got %d", r.NumBytes)
+	}
+}
+
+func TestXmlEncode(t *testing.T) {
+	b, err := XmlCodec.Encode(&Request{Trip: &Trip{DisplayName: "My Test Trip", StartDate: Date{2011, 12, 9}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `<Request><Trip><start_date>2011-12-09</start_date><display_name>My Test Trip</display_name></Trip></Request>`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+
+	b, err = XmlCodec.Encode(&Profile{Attributes: ProfileAttributes{Ref: "abc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(b, []byte(`<Profile ref="abc">`)) {
+		t.Errorf("Expected ref attribute, got %s", b)
+	}
+}
+
+func TestXmlClient(t *testing.T) {
+	var paths []string
+	var form string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Method == "POST" {
+			form = r.FormValue("xml")
+		}
+		w.Write([]byte(tripXML))
+	}))
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()), WithCodec(XmlCodec))
+	ctx := context.Background()
+
+	trip, err := c.GetTrip(ctx, 1)
+	if err != nil || trip.DisplayName != "Cancun" {
+		t.Errorf("Unexpected trip: %v, %v", trip, err)
+	}
+	if _, err := c.CreateContext(ctx, &Request{Trip: &Trip{DisplayName: "Cancun"}}); err != nil {
+		t.Error(err)
+	}
+	if len(paths) != 2 || paths[0] != "/v1/get/trip/id/1/format/xml" || paths[1] != "/v1/create/format/xml" {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+	if !strings.HasPrefix(form, "<Request><Trip>") {
+		t.Errorf("Expected XML form value, got %q", form)
+	}
+}
+
+// largeResponse returns a JSON response with n air objects.
+func largeResponse(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"timestamp":"1306543281","Profile":{"@attributes":{"ref":"abc"},"screen_name":"traveler"},"AirObject":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":"%d","trip_id":"1","display_name":"Flight %d","notes":"Window seat","Segment":[`+
+			`{"StartDateTime":{"date":"2011-12-09","time":"08:00:00","timezone":"America/New_York","utc_offset":"-05:00"},`+
+			`"EndDateTime":{"date":"2011-12-09","time":"12:00:00","timezone":"America/Cancun","utc_offset":"-05:00"},`+
+			`"start_airport_code":"JFK","end_airport_code":"CUN","marketing_airline":"JetBlue","marketing_flight_number":"%d"}]}`, i, i, i)
+	}
+	b.WriteString(`]}`)
+	return b.Bytes()
+}
+
+// BenchmarkDecodeBuffered measures the previous decoding path, which read the whole body
+// and replaced "@attributes" before unmarshaling.
+func BenchmarkDecodeBuffered(b *testing.B) {
+	data := largeResponse(1000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, err := ioutil.ReadAll(bytes.NewReader(data))
+		if err != nil {
+			b.Fatal(err)
+		}
+		buf = bytes.Replace(buf, []byte("\"@attributes\""), []byte("\"_attributes\""), -1)
+		if err := json.Unmarshal(buf, new(Response)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeStreaming measures decoding from the body with the codec, which decodes
+// the air objects one at a time. It allocates about half the bytes of the buffered path,
+// and memory no longer grows with the size of the response. The number of allocations,
+// which is mostly the decoded values themselves, stays about the same.
+func BenchmarkDecodeStreaming(b *testing.B) {
+	data := largeResponse(1000)
+	c := &TripIt{codec: JsonCodec}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := c.codec.Decode(c.limitBody(bytes.NewReader(data)), new(Response)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tripit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CRS (reservation system) partner calls
+const (
+	CrsLoadReservations   = "crsLoadReservations"
+	CrsDeleteReservations = "crsDeleteReservations"
+)
+
+// CrsLoadRequest contains reservations to load into TripIt with CrsLoad.
+type CrsLoadRequest struct {
+	CompanyKey    string   // optional, the partner's company key
+	Reservations  *Request // reservation objects, for example AirObject and LodgingObject with booking details
+	RecordLocator string   // optional, record locator of the reservations; required with Remarks
+	Remarks       []string // optional, remark text, one entry per remark
+}
+
+// CrsDeleteRequest identifies reservations to delete from TripIt with CrsDelete.
+type CrsDeleteRequest struct {
+	CompanyKey    string // optional, the partner's company key
+	RecordLocator string // record locator of the reservations
+}
+
+// CrsLoad loads reservations from a reservation system into TripIt. TripIt adds the
+// objects to the matching trips of the travelers, creating trips as needed. Remarks are
+// sent in the same request as TripCrsRemark entries on its Trip, so they are loaded
+// together with the reservations they belong to; the caller's Request is not modified.
+func (t *TripIt) CrsLoad(ctx context.Context, r *CrsLoadRequest) (*Response, error) {
+	if r.Reservations == nil {
+		return nil, fmt.Errorf("tripit: no reservations to load")
+	}
+	req := r.Reservations
+	if len(r.Remarks) > 0 {
+		if r.RecordLocator == "" {
+			return nil, fmt.Errorf("tripit: record locator is required with remarks")
+		}
+		remarks := make(TripCrsRemarkVector, len(r.Remarks))
+		for i, notes := range r.Remarks {
+			remarks[i] = TripCrsRemark{RecordLocator: r.RecordLocator, Notes: notes}
+		}
+		var trip Trip
+		if req.Trip != nil {
+			trip = *req.Trip
+		}
+		trip.TripCrsRemarks = &TripCrsRemarks{TripCrsRemark: remarks}
+		c := *req
+		c.Trip = &trip
+		req = &c
+	}
+	return t.postForm(ctx, CrsLoadReservations, req, crsParams(r.CompanyKey))
+}
+
+// CrsDelete deletes the reservations with the given record locator from TripIt.
+func (t *TripIt) CrsDelete(ctx context.Context, r *CrsDeleteRequest) (*Response, error) {
+	if r.RecordLocator == "" {
+		return nil, fmt.Errorf("tripit: record locator is required")
+	}
+	u := fmt.Sprintf("%s/%s/%s/record_locator/%s/format/%s", t.baseUrl, t.version, CrsDeleteReservations,
+		url.PathEscape(r.RecordLocator), t.codec.Format())
+	params := crsParams(r.CompanyKey)
+	if params != nil {
+		q := make(url.Values)
+		for k, v := range params {
+			q.Set(k, v)
+		}
+		u += "?" + q.Encode()
+	}
+	// The query parameters are passed as args so that they are included in the OAuth signature.
+	return t.makeRequest(ctx, &apiRequest{method: "GET", url: u, args: params})
+}
+
+// crsParams returns the additional parameters for a CRS call.
+func crsParams(companyKey string) map[string]string {
+	if companyKey == "" {
+		return nil
+	}
+	return map[string]string{"company_key": companyKey}
+}
@@ -0,0 +1,84 @@
+package tripit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrs(t *testing.T) {
+	cred := NewOAuth2LeggedCredential("key", "secret", "requestor")
+	var paths []string
+	var requests []Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		// The company key is signed both in the form of a post and in the query of a get.
+		if !checkSignedForm(t, cred, r) {
+			return
+		}
+		if k := r.FormValue("company_key"); k != "agency" {
+			t.Errorf("Unexpected company key %q", k)
+		}
+		if r.Method == "POST" {
+			var req Request
+			if err := json.Unmarshal([]byte(r.PostFormValue("json")), &req); err != nil {
+				t.Error(err)
+			}
+			requests = append(requests, req)
+		}
+		w.Write([]byte(`{"Trip":{"id":"1","TripCrsRemarks":{"TripCrsRemark":{"record_locator":"ABC123","notes":"Aisle"}}},` +
+			`"AirObject":{"id":"2","trip_id":"1","booking_site_conf_num":"ABC123"}}`))
+	}))
+	defer srv.Close()
+	c := NewClient(cred, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+	ctx := context.Background()
+
+	resp, err := c.CrsLoad(ctx, &CrsLoadRequest{CompanyKey: "agency", Reservations: &Request{AirObject: &AirObject{SupplierConfNum: "ABC123"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.AirObject) != 1 || resp.AirObject[0].TripId != "1" {
+		t.Errorf("Unexpected air objects: %+v", resp.AirObject)
+	}
+	load := &CrsLoadRequest{CompanyKey: "agency", Reservations: &Request{Trip: &Trip{DisplayName: "Sales trip"}, AirObject: &AirObject{SupplierConfNum: "ABC123"}},
+		RecordLocator: "ABC123", Remarks: []string{"Aisle", "Vegetarian"}}
+	resp, err = c.CrsLoad(ctx, load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := resp.Trip[0].TripCrsRemarks; r == nil || len(r.TripCrsRemark) != 1 || r.TripCrsRemark[0].Notes != "Aisle" {
+		t.Errorf("Unexpected remarks: %+v", r)
+	}
+	if load.Reservations.Trip.TripCrsRemarks != nil {
+		t.Error("Expected the caller's request to be unchanged")
+	}
+	if _, err := c.CrsDelete(ctx, &CrsDeleteRequest{CompanyKey: "agency", RecordLocator: "ABC/123"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CrsLoad(ctx, &CrsLoadRequest{Reservations: &Request{}, Remarks: []string{"Aisle"}}); err == nil {
+		t.Error("Expected error for remarks without a record locator")
+	}
+	if _, err := c.CrsDelete(ctx, &CrsDeleteRequest{}); err == nil {
+		t.Error("Expected error for missing record locator")
+	}
+
+	expected := []string{"/v1/crsLoadReservations/format/json", "/v1/crsLoadReservations/format/json", "/v1/crsDeleteReservations/record_locator/ABC%2F123/format/json"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected paths %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Expected path %s, got %s", expected[i], paths[i])
+		}
+	}
+	if len(requests) != 2 || requests[0].AirObject == nil || requests[0].AirObject.SupplierConfNum != "ABC123" {
+		t.Fatalf("Unexpected requests: %+v", requests)
+	}
+	if r := requests[1]; r.AirObject == nil || r.Trip == nil || r.Trip.DisplayName != "Sales trip" {
+		t.Errorf("Unexpected request: %+v", r)
+	} else if rs := r.Trip.TripCrsRemarks.TripCrsRemark; len(rs) != 2 || rs[1].RecordLocator != "ABC123" || rs[1].Notes != "Vegetarian" {
+		t.Errorf("Unexpected remarks: %+v", rs)
+	}
+}
@@ -0,0 +1,109 @@
+package tripit
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Date is a calendar date without a time of day or time zone, used for xs:date values.
+// The zero value represents an unset date. It is encoded as an empty string, and fields
+// tagged omitzero leave it out.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate parses a date in the form "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+// String returns the date in the form "2006-01-02", or an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.In(time.UTC).Format("2006-01-02")
+}
+
+// IsZero returns true if the date is not set.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns midnight at the start of the date in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// utcTime returns midnight UTC at the start of the date, or ErrNoDateTime if the date is not set.
+func (d Date) utcTime() (time.Time, error) {
+	if d.IsZero() {
+		return time.Time{}, ErrNoDateTime
+	}
+	return d.In(time.UTC), nil
+}
+
+// Before returns true if d is before o.
+func (d Date) Before(o Date) bool {
+	if d.Year != o.Year {
+		return d.Year < o.Year
+	}
+	if d.Month != o.Month {
+		return d.Month < o.Month
+	}
+	return d.Day < o.Day
+}
+
+// After returns true if d is after o.
+func (d Date) After(o Date) bool {
+	return o.Before(d)
+}
+
+// AddDays returns the date n days after d. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// DaysBetween returns the number of days from d to o, which is negative if o is before d.
+func (d Date) DaysBetween(o Date) int {
+	return int(o.In(time.UTC).Sub(d.In(time.UTC)) / (24 * time.Hour))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalXML implements xml.Marshaler. The zero Date is omitted.
+func (d Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(d.String(), start)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty string gives the zero Date.
+func (d *Date) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*d = Date{}
+		return nil
+	}
+	v, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,78 @@
+package tripit
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2011-12-30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := d.AddDays(3)
+	if e.String() != "2012-01-02" || d.DaysBetween(e) != 3 || e.DaysBetween(d) != -3 {
+		t.Errorf("Unexpected date arithmetic: %v %d", e, d.DaysBetween(e))
+	}
+	if !d.Before(e) || !e.After(d) || d.After(e) || d.Before(d) {
+		t.Error("Unexpected date comparison")
+	}
+	// DST changes don't affect the number of days.
+	if n := (Date{2011, 3, 1}).DaysBetween(Date{2011, 4, 1}); n != 31 {
+		t.Errorf("Expected 31 days, got %d", n)
+	}
+}
+
+func TestDateJson(t *testing.T) {
+	var trip Trip
+	if err := json.Unmarshal([]byte(`{"start_date":"2011-12-09","end_date":""}`), &trip); err != nil {
+		t.Fatal(err)
+	}
+	if trip.StartDate != (Date{2011, 12, 9}) || !trip.EndDate.IsZero() {
+		t.Errorf("Unexpected dates: %v %v", trip.StartDate, trip.EndDate)
+	}
+	b, err := json.Marshal(&trip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An unset date is left out rather than sent as an empty string.
+	if string(b) != `{"start_date":"2011-12-09"}` {
+		t.Errorf("Unexpected JSON: %s", b)
+	}
+	if b, err := json.Marshal(&Request{Trip: &Trip{DisplayName: "x"}}); err != nil || string(b) != `{"Trip":{"display_name":"x"}}` {
+		t.Errorf("Unexpected JSON: %s, %v", b, err)
+	}
+	if b, err := json.Marshal(Date{}); err != nil || string(b) != `""` {
+		t.Errorf("Unexpected JSON for the zero Date: %s, %v", b, err)
+	}
+	if err := json.Unmarshal([]byte(`{"start_date":"12/09/2011"}`), &trip); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+}
+
+func TestItineraryDates(t *testing.T) {
+	// Crossing the dateline: leave Los Angeles late on the 9th, arrive in Sydney on the
+	// 11th, and return on the 20th, arriving in Los Angeles before departing Sydney.
+	const s = `{
+"Trip":{"id":"1","start_date":"2011-12-09","end_date":"2011-12-20"},
+"AirObject":{"id":"10","trip_id":"1","Segment":[
+	{"StartDateTime":{"date":"2011-12-09","time":"23:00:00","timezone":"America/Los_Angeles"},
+	 "EndDateTime":{"date":"2011-12-11","time":"07:00:00","timezone":"Australia/Sydney"}},
+	{"StartDateTime":{"date":"2011-12-20","time":"10:00:00","timezone":"Australia/Sydney"},
+	 "EndDateTime":{"date":"2011-12-20","time":"06:00:00","timezone":"America/Los_Angeles"}}
+]}}`
+	var resp Response
+	if err := json.NewDecoder(strings.NewReader(s)).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	it := NewItineraries(&resp)[0]
+	start, _ := it.Start()
+	end, _ := it.End()
+	if start.Location().String() != "America/Los_Angeles" || start.Day() != 9 || end.Day() != 20 || end.Hour() != 6 {
+		t.Errorf("Unexpected start and end: %v %v", start, end)
+	}
+	if n := it.Days(); n != 12 {
+		t.Errorf("Expected 12 days, got %d", n)
+	}
+}
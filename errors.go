@@ -0,0 +1,37 @@
+package tripit
+
+import (
+	"fmt"
+)
+
+// APIError is returned when TripIt responds with an HTTP error status, or when a
+// successful response contains Error entries and response errors are enabled with
+// SetResponseErrors. Use errors.As to retrieve it from an error returned by the client.
+type APIError struct {
+	StatusCode int           // HTTP status code
+	Status     string        // HTTP status, for example "401 Unauthorized"
+	Body       []byte        // raw response body, for HTTP error statuses
+	Errors     ErrorVector   // Error entries returned by TripIt, if any
+	Warnings   WarningVector // Warning entries returned by TripIt, if any
+	Response   *Response     // decoded response, if the body could be decoded
+}
+
+// Error returns a string containing the error information.
+func (e *APIError) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("%s (HTTP %d)", e.Errors[0].Error(), e.StatusCode)
+	}
+	if e.Status != "" {
+		return fmt.Sprintf("TripIt HTTP error: %s", e.Status)
+	}
+	return fmt.Sprintf("TripIt HTTP error: %d", e.StatusCode)
+}
+
+// Unwrap returns the first Error entry returned by TripIt, so that errors.As can
+// also be used to retrieve an *Error.
+func (e *APIError) Unwrap() error {
+	if len(e.Errors) > 0 {
+		return &e.Errors[0]
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidFilter is returned when a list filter contains an invalid value or combination.
+var ErrInvalidFilter = errors.New("tripit: invalid list filter")
+
+// ListFilter is implemented by the list filters. Params validates the filter and returns
+// the filter parameters to send to TripIt.
+type ListFilter interface {
+	ListType() string                   // list object type: trip, object or points_program
+	Params() (map[string]string, error) // validated filter parameters
+}
+
+// TravelerFilter selects objects based on whether the user is a traveler.
+type TravelerFilter int
+
+// Traveler filter values
+const (
+	TravelerUnset TravelerFilter = iota // don't filter; TripIt's default is true
+	TravelerTrue                        // only objects where the user is a traveler
+	TravelerFalse                       // only objects where the user is not a traveler
+	TravelerAll                         // all objects
+)
+
+// String returns the filter value sent to TripIt.
+func (f TravelerFilter) String() string {
+	switch f {
+	case TravelerTrue:
+		return "true"
+	case TravelerFalse:
+		return "false"
+	case TravelerAll:
+		return "all"
+	}
+	return ""
+}
+
+// objectListTypes are the object types that can be used with ObjectListFilter.Type.
+var objectListTypes = map[string]bool{
+	ObjectTypeAir:        true,
+	ObjectTypeActivity:   true,
+	ObjectTypeCar:        true,
+	ObjectTypeCruise:     true,
+	ObjectTypeDirections: true,
+	ObjectTypeLodging:    true,
+	ObjectTypeMap:        true,
+	ObjectTypeNote:       true,
+	ObjectTypeRail:       true,
+	ObjectTypeRestaurant: true,
+	ObjectTypeTransport:  true,
+}
+
+// TripListFilter filters the trips returned by a trip list.
+type TripListFilter struct {
+	Traveler       TravelerFilter // optional
+	Past           bool           // list past trips instead of upcoming ones
+	ModifiedSince  time.Time      // optional, only trips modified since this time
+	IncludeObjects bool           // also return the objects in each trip
+	PageNum        int            // optional, page number to retrieve
+	PageSize       int            // optional, number of trips per page
+}
+
+// ListType returns ListTrip.
+func (f *TripListFilter) ListType() string {
+	return ListTrip
+}
+
+// Params validates the filter and returns the filter parameters.
+func (f *TripListFilter) Params() (map[string]string, error) {
+	p := make(map[string]string)
+	if err := commonParams(p, f.Traveler, f.Past, f.ModifiedSince, f.PageNum, f.PageSize); err != nil {
+		return nil, err
+	}
+	if f.IncludeObjects {
+		p[FilterIncludeObjects] = "true"
+	}
+	return p, nil
+}
+
+// ObjectListFilter filters the objects returned by an object list.
+type ObjectListFilter struct {
+	Traveler      TravelerFilter // optional
+	Past          bool           // list objects in past trips instead of upcoming ones
+	ModifiedSince time.Time      // optional, only objects modified since this time
+	TripId        uint           // optional, only objects in this trip
+	Type          string         // optional, one of the ObjectType constants other than ObjectTypeTrip
+	PageNum       int            // optional, page number to retrieve
+	PageSize      int            // optional, number of objects per page
+}
+
+// ListType returns ListObject.
+func (f *ObjectListFilter) ListType() string {
+	return ListObject
+}
+
+// Params validates the filter and returns the filter parameters.
+func (f *ObjectListFilter) Params() (map[string]string, error) {
+	p := make(map[string]string)
+	if err := commonParams(p, f.Traveler, f.Past, f.ModifiedSince, f.PageNum, f.PageSize); err != nil {
+		return nil, err
+	}
+	if f.TripId != 0 {
+		p[FilterTripId] = strconv.FormatUint(uint64(f.TripId), 10)
+	}
+	if f.Type != "" {
+		if !objectListTypes[f.Type] {
+			return nil, fmt.Errorf("%w: unknown object type %q", ErrInvalidFilter, f.Type)
+		}
+		p[FilterType] = f.Type
+	}
+	return p, nil
+}
+
+// PointsProgramListFilter is used to list points programs. TripIt does not support any
+// filters on points program lists.
+type PointsProgramListFilter struct{}
+
+// ListType returns ListPointsProgram.
+func (f *PointsProgramListFilter) ListType() string {
+	return ListPointsProgram
+}
+
+// Params returns no filter parameters.
+func (f *PointsProgramListFilter) Params() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// commonParams validates and adds the parameters shared by trip and object filters.
+func commonParams(p map[string]string, traveler TravelerFilter, past bool, modifiedSince time.Time, pageNum, pageSize int) error {
+	switch traveler {
+	case TravelerUnset:
+	case TravelerTrue, TravelerFalse, TravelerAll:
+		p[FilterTraveler] = traveler.String()
+	default:
+		return fmt.Errorf("%w: invalid traveler value %d", ErrInvalidFilter, traveler)
+	}
+	if past {
+		p[FilterPast] = "true"
+	}
+	if !modifiedSince.IsZero() {
+		if modifiedSince.Unix() < 0 {
+			return fmt.Errorf("%w: modified since %v is before 1970", ErrInvalidFilter, modifiedSince)
+		}
+		p[FilterModifiedSince] = strconv.FormatInt(modifiedSince.Unix(), 10)
+	}
+	if pageNum < 0 || pageSize < 0 {
+		return fmt.Errorf("%w: negative page number or size", ErrInvalidFilter)
+	}
+	if pageNum > 0 {
+		p[FilterPageNum] = strconv.Itoa(pageNum)
+	}
+	if pageSize > 0 {
+		p[FilterPageSize] = strconv.Itoa(pageSize)
+	}
+	return nil
+}
+
+// ListFiltered lists objects using the given filter, and returns the response object from TripIt.
+func (t *TripIt) ListFiltered(ctx context.Context, f ListFilter) (*Response, error) {
+	p, err := f.Params()
+	if err != nil {
+		return nil, err
+	}
+	return t.ListContext(ctx, f.ListType(), p)
+}
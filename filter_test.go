@@ -0,0 +1,63 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListFilterParams(t *testing.T) {
+	f := &TripListFilter{Traveler: TravelerAll, Past: true, ModifiedSince: time.Unix(1306543281, 0), IncludeObjects: true, PageNum: 2, PageSize: 10}
+	p, err := f.Params()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 6 || p[FilterTraveler] != "all" || p[FilterModifiedSince] != "1306543281" || p[FilterIncludeObjects] != "true" || p[FilterPageNum] != "2" {
+		t.Errorf("Unexpected trip filter params: %v", p)
+	}
+
+	p, err = (&ObjectListFilter{TripId: 42, Type: ObjectTypeAir}).Params()
+	if err != nil || len(p) != 2 || p[FilterTripId] != "42" || p[FilterType] != "air" {
+		t.Errorf("Unexpected object filter params: %v, %v", p, err)
+	}
+
+	invalid := []ListFilter{
+		&ObjectListFilter{Type: "banana"},
+		&ObjectListFilter{Type: ObjectTypeTrip},
+		&TripListFilter{Traveler: TravelerFilter(7)},
+		&TripListFilter{PageNum: -1},
+	}
+	for _, f := range invalid {
+		if _, err := f.Params(); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("Expected ErrInvalidFilter for %+v, got %v", f, err)
+		}
+	}
+}
+
+func TestListFiltered(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+
+	for i := 0; i < 5; i++ {
+		_, err := c.ListFiltered(context.Background(), &ObjectListFilter{Traveler: TravelerFalse, Past: true, TripId: 7, Type: ObjectTypeLodging})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != "/v1/list/object/past/true/traveler/false/trip_id/7/type/lodging/format/json" {
+			t.Fatalf("Unexpected path: %s", path)
+		}
+	}
+
+	path = ""
+	if _, err := c.ListFiltered(context.Background(), &ObjectListFilter{Type: "banana"}); err == nil || path != "" {
+		t.Errorf("Expected invalid filter to fail without a request, got %v", err)
+	}
+}
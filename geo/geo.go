@@ -0,0 +1,344 @@
+// Package geo exports the geography of TripIt trips as GeoJSON (RFC 7946) and KML.
+// Flights become great-circle lines between airports; lodging, activities, restaurants
+// and maps become points; and directions become lines between their addresses.
+package geo
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ancientlore/go-tripit"
+)
+
+// earthRadius is the mean radius of the earth in kilometers.
+const earthRadius = 6371.0
+
+// Position is a location in degrees.
+type Position struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// valid returns true if the position is set.
+func (p Position) valid() bool {
+	return p.Longitude != 0 || p.Latitude != 0
+}
+
+// Feature is a place or a route with its properties.
+type Feature struct {
+	Name       string
+	Point      *Position              // location of a place; nil for routes
+	Lines      [][]Position           // route; split into several lines where it crosses the antimeridian
+	Properties map[string]interface{} // values are strings or numbers
+}
+
+// Collection is a list of features that can be written as GeoJSON or KML.
+type Collection struct {
+	Name     string // optional, name of the KML document
+	Features []*Feature
+}
+
+// AddItinerary adds the features of all objects in the itinerary.
+func (c *Collection) AddItinerary(it *tripit.Itinerary) {
+	c.AddRange(it, tripit.Date{}, tripit.Date{})
+}
+
+// AddRange adds the features of the objects and segments in the itinerary that take place
+// between from and to, inclusive. Dates are compared in the time zone of each object, and
+// objects such as lodging are included if any of their dates are in the range. A zero
+// date leaves that end of the range open. Objects without a time are only added if both
+// dates are zero, and objects without coordinates are not added.
+func (c *Collection) AddRange(it *tripit.Itinerary, from, to tripit.Date) {
+	open := from.IsZero() && to.IsZero()
+	for i := range it.Items {
+		item := &it.Items[i]
+		if item.Time.IsZero() {
+			if !open {
+				continue
+			}
+		} else {
+			end := tripit.DateOf(itemEnd(item))
+			if !from.IsZero() && end.Before(from) || !to.IsZero() && tripit.DateOf(item.Time).After(to) {
+				continue
+			}
+		}
+		if f := newFeature(item); f != nil {
+			c.Features = append(c.Features, f)
+		}
+	}
+}
+
+// itemEnd returns the end of an item, or its start if the end is unknown.
+func itemEnd(item *tripit.ItineraryItem) time.Time {
+	var end time.Time
+	var err error
+	switch s := item.Segment.(type) {
+	case *tripit.AirSegment:
+		if s.EndDateTime != nil {
+			end, err = s.EndDateTime.GetTime()
+		}
+	case nil:
+		if o, ok := item.Object.(tripit.Object); ok {
+			end, err = o.End()
+		}
+	}
+	if err != nil || end.Before(item.Time) {
+		return item.Time
+	}
+	return end
+}
+
+// newFeature returns the feature for an item, or nil if it has no coordinates.
+func newFeature(item *tripit.ItineraryItem) *Feature {
+	if s, ok := item.Segment.(*tripit.AirSegment); ok {
+		o, ok := item.Object.(*tripit.AirObject)
+		if !ok {
+			return nil
+		}
+		return flightFeature(o, s)
+	}
+	var f *Feature
+	switch o := item.Object.(type) {
+	case *tripit.LodgingObject:
+		f = pointFeature(o.Address)
+	case *tripit.ActivityObject:
+		f = pointFeature(o.Address)
+		if f != nil && o.LocationName != "" {
+			f.Properties["location"] = o.LocationName
+		}
+	case *tripit.RestaurantObject:
+		f = pointFeature(o.Address)
+		if f != nil && o.Cuisine != "" {
+			f.Properties["cuisine"] = o.Cuisine
+		}
+	case *tripit.MapObject:
+		if f = pointFeature(o.Address); f != nil {
+			f.Name = o.DisplayName
+			setProperties(f, tripit.ObjectTypeMap, o.Id, o.TripId, o.DisplayName)
+			setTimes(f, item.Time, time.Time{})
+		}
+		return f
+	case *tripit.DirectionsObject:
+		if f = directionsFeature(o); f != nil {
+			setTimes(f, item.Time, time.Time{})
+		}
+		return f
+	}
+	o, ok := item.Object.(tripit.Object)
+	if f == nil || !ok {
+		return nil
+	}
+	f.Name = o.Name()
+	if f.Name == "" {
+		f.Name = o.Booking().SupplierName
+	}
+	setProperties(f, o.ObjectType(), o.ID(), o.TripID(), f.Name)
+	setBooking(f, o.Booking())
+	setTimes(f, item.Time, itemEnd(item))
+	return f
+}
+
+// flightFeature returns a great-circle route for a flight, or nil if the coordinates of
+// either airport are unknown.
+func flightFeature(o *tripit.AirObject, s *tripit.AirSegment) *Feature {
+	from := Position{s.StartAirportLongitude, s.StartAirportLatitude}
+	to := Position{s.EndAirportLongitude, s.EndAirportLatitude}
+	if !from.valid() || !to.valid() {
+		return nil
+	}
+	airline := s.MarketingAirlineCode
+	if airline == "" {
+		airline = s.MarketingAirline
+	}
+	f := &Feature{Lines: splitAntimeridian(greatCircle(from, to))}
+	f.Name = airline + s.MarketingFlightNumber
+	if s.StartAirportCode != "" && s.EndAirportCode != "" {
+		f.Name += " " + s.StartAirportCode + "-" + s.EndAirportCode
+	}
+	id := o.Id
+	if s.Id != "" {
+		id = s.Id
+	}
+	setProperties(f, tripit.ObjectTypeAir, id, o.TripId, f.Name)
+	setBooking(f, o.Booking())
+	set(f, "flight", airline+s.MarketingFlightNumber)
+	set(f, "from", s.StartAirportCode)
+	set(f, "to", s.EndAirportCode)
+	set(f, "aircraft", s.AircraftDisplayName)
+	f.Properties["distance_km"] = math.Round(Distance(from, to)*10) / 10
+	var start, end time.Time
+	if s.StartDateTime != nil {
+		start, _ = s.StartDateTime.GetTime()
+	}
+	if s.EndDateTime != nil {
+		end, _ = s.EndDateTime.GetTime()
+	}
+	setTimes(f, start, end)
+	return f
+}
+
+// pointFeature returns a point at the address, or nil if its coordinates are unknown.
+func pointFeature(a *tripit.Address) *Feature {
+	p, ok := position(a)
+	if !ok {
+		return nil
+	}
+	f := &Feature{Point: &p, Properties: make(map[string]interface{})}
+	set(f, "address", formatAddress(a))
+	return f
+}
+
+// directionsFeature returns a straight line between the addresses of the directions, or
+// nil if the coordinates of either address are unknown.
+func directionsFeature(o *tripit.DirectionsObject) *Feature {
+	from, ok := position(o.StartAddress)
+	if !ok {
+		return nil
+	}
+	to, ok := position(o.EndAddress)
+	if !ok {
+		return nil
+	}
+	f := &Feature{Name: o.DisplayName, Lines: [][]Position{{from, to}}}
+	setProperties(f, tripit.ObjectTypeDirections, o.Id, o.TripId, o.DisplayName)
+	set(f, "from", formatAddress(o.StartAddress))
+	set(f, "to", formatAddress(o.EndAddress))
+	return f
+}
+
+// position returns the coordinates of an address.
+func position(a *tripit.Address) (Position, bool) {
+	if a == nil {
+		return Position{}, false
+	}
+	p := Position{a.Longitude, a.Latitude}
+	return p, p.valid()
+}
+
+// setProperties sets the properties common to all features.
+func setProperties(f *Feature, objectType, id, tripId, name string) {
+	if f.Properties == nil {
+		f.Properties = make(map[string]interface{})
+	}
+	f.Properties["type"] = objectType
+	set(f, "id", id)
+	set(f, "trip_id", tripId)
+	set(f, "name", name)
+}
+
+// setBooking sets the supplier and confirmation number properties.
+func setBooking(f *Feature, b tripit.Booking) {
+	set(f, "supplier", b.SupplierName)
+	conf := b.SupplierConfNum
+	if conf == "" {
+		conf = b.SiteConfNum
+	}
+	set(f, "confirmation", conf)
+}
+
+// setTimes sets the start and end properties, in RFC 3339 format with the local offset.
+func setTimes(f *Feature, start, end time.Time) {
+	if !start.IsZero() {
+		f.Properties["start"] = start.Format(time.RFC3339)
+	}
+	if end.After(start) {
+		f.Properties["end"] = end.Format(time.RFC3339)
+	}
+}
+
+// set sets a string property if the value is not empty.
+func set(f *Feature, name, value string) {
+	if value != "" {
+		f.Properties[name] = value
+	}
+}
+
+// formatAddress returns a one-line address.
+func formatAddress(a *tripit.Address) string {
+	if a.Address != "" {
+		return a.Address
+	}
+	var parts []string
+	for _, s := range []string{a.Addr1, a.Addr2, a.City, a.State, a.Zip, a.Country} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Distance returns the great-circle distance between two positions in kilometers.
+func Distance(a, b Position) float64 {
+	return centralAngle(a, b) * earthRadius
+}
+
+// centralAngle returns the angle between two positions in radians, using the haversine formula.
+func centralAngle(a, b Position) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dlat, dlon := lat2-lat1, radians(b.Longitude-a.Longitude)
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlon/2)*math.Sin(dlon/2)
+	return 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// greatCircle returns positions along the shortest route between two positions, about
+// one degree of arc apart.
+func greatCircle(a, b Position) []Position {
+	d := centralAngle(a, b)
+	n := int(math.Ceil(d * 180 / math.Pi))
+	if n < 1 || math.Abs(d-math.Pi) < 1e-9 {
+		// The same position, or antipodes, for which the route is not defined.
+		return []Position{a, b}
+	}
+	lat1, lon1 := radians(a.Latitude), radians(a.Longitude)
+	lat2, lon2 := radians(b.Latitude), radians(b.Longitude)
+	result := make([]Position, 0, n+1)
+	result = append(result, a)
+	for i := 1; i < n; i++ {
+		f := float64(i) / float64(n)
+		x1 := math.Sin((1-f)*d) / math.Sin(d)
+		x2 := math.Sin(f*d) / math.Sin(d)
+		x := x1*math.Cos(lat1)*math.Cos(lon1) + x2*math.Cos(lat2)*math.Cos(lon2)
+		y := x1*math.Cos(lat1)*math.Sin(lon1) + x2*math.Cos(lat2)*math.Sin(lon2)
+		z := x1*math.Sin(lat1) + x2*math.Sin(lat2)
+		result = append(result, Position{
+			Longitude: round(degrees(math.Atan2(y, x))),
+			Latitude:  round(degrees(math.Atan2(z, math.Sqrt(x*x+y*y)))),
+		})
+	}
+	return append(result, b)
+}
+
+// splitAntimeridian splits a line where it crosses longitude 180, as RFC 7946 requires.
+func splitAntimeridian(line []Position) [][]Position {
+	var result [][]Position
+	current := []Position{line[0]}
+	for i := 1; i < len(line); i++ {
+		a, b := line[i-1], line[i]
+		if math.Abs(b.Longitude-a.Longitude) > 180 {
+			edge := math.Copysign(180, a.Longitude)
+			blon := b.Longitude + 2*edge // b's longitude continued past the edge
+			t := (edge - a.Longitude) / (blon - a.Longitude)
+			lat := round(a.Latitude + t*(b.Latitude-a.Latitude))
+			result = append(result, append(current, Position{edge, lat}))
+			current = []Position{{-edge, lat}}
+		}
+		current = append(current, b)
+	}
+	return append(result, current)
+}
+
+// radians converts degrees to radians.
+func radians(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+// degrees converts radians to degrees.
+func degrees(r float64) float64 {
+	return r * 180 / math.Pi
+}
+
+// round rounds to 6 decimal places, about 10 cm.
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
@@ -0,0 +1,206 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ancientlore/go-tripit"
+)
+
+const tripJSON = `{
+"Trip":{"id":"1","display_name":"Around the world"},
+"AirObject":{"id":"10","trip_id":"1","supplier_conf_num":"ABC123","Segment":[
+	{"id":"11","StartDateTime":{"date":"2011-10-28","time":"18:00:00","timezone":"America/New_York"},"EndDateTime":{"date":"2011-10-29","time":"06:30:00","timezone":"Europe/London"},
+	 "start_airport_code":"JFK","start_airport_latitude":"40.6398","start_airport_longitude":"-73.7789",
+	 "end_airport_code":"LHR","end_airport_latitude":"51.4706","end_airport_longitude":"-0.4619","marketing_airline_code":"XA","marketing_flight_number":"100"},
+	{"id":"12","StartDateTime":{"date":"2011-11-05","time":"17:00:00","timezone":"Asia/Tokyo"},"EndDateTime":{"date":"2011-11-05","time":"10:00:00","timezone":"America/Los_Angeles"},
+	 "start_airport_code":"NRT","start_airport_latitude":"35.7647","start_airport_longitude":"140.3864",
+	 "end_airport_code":"SFO","end_airport_latitude":"37.6190","end_airport_longitude":"-122.3749","marketing_airline_code":"XA","marketing_flight_number":"200"},
+	{"id":"13","StartDateTime":{"date":"2011-11-06","time":"09:00:00","timezone":"America/Los_Angeles"},"start_airport_code":"SFO","end_airport_code":"LAX"}
+]},
+"LodgingObject":{"id":"20","trip_id":"1","supplier_name":"The Hotel",
+	"StartDateTime":{"date":"2011-10-29","time":"15:00:00","timezone":"Europe/London"},"EndDateTime":{"date":"2011-11-01","time":"11:00:00","timezone":"Europe/London"},
+	"Address":{"address":"1 Main St, London","latitude":"51.5","longitude":"-0.12"}},
+"RestaurantObject":{"id":"30","trip_id":"1","display_name":"Dinner","cuisine":"Sushi",
+	"DateTime":{"date":"2011-11-04","time":"19:00:00","timezone":"Asia/Tokyo"},"Address":{"city":"Tokyo","country":"JP","latitude":"35.6895","longitude":"139.6917"}},
+"ActivityObject":{"id":"40","trip_id":"1","display_name":"No coordinates","StartDateTime":{"date":"2011-11-02","time":"10:00:00","timezone":"Europe/Paris"}},
+"DirectionsObject":{"id":"50","trip_id":"1","display_name":"To the hotel",
+	"StartAddress":{"address":"LHR","latitude":"51.4706","longitude":"-0.4619"},"EndAddress":{"address":"1 Main St, London","latitude":"51.5","longitude":"-0.12"}}
+}`
+
+// testItinerary returns the itinerary for the test trip.
+func testItinerary(t *testing.T) *tripit.Itinerary {
+	var resp tripit.Response
+	if err := json.Unmarshal([]byte(tripJSON), &resp); err != nil {
+		t.Fatal(err)
+	}
+	its := tripit.NewItineraries(&resp)
+	if len(its) != 1 {
+		t.Fatalf("Expected 1 itinerary, got %d", len(its))
+	}
+	return its[0]
+}
+
+func TestAddItinerary(t *testing.T) {
+	var c Collection
+	c.AddItinerary(testItinerary(t))
+	var types []string
+	for _, f := range c.Features {
+		types = append(types, f.Properties["type"].(string))
+	}
+	if strings.Join(types, ",") != "air,lodging,restaurant,air,directions" {
+		t.Fatalf("Unexpected features: %v", types)
+	}
+
+	flight := c.Features[0]
+	if flight.Name != "XA100 JFK-LHR" || flight.Properties["confirmation"] != "ABC123" || flight.Properties["id"] != "11" ||
+		flight.Properties["start"] != "2011-10-28T18:00:00-04:00" || flight.Properties["end"] != "2011-10-29T06:30:00+01:00" {
+		t.Errorf("Unexpected flight properties: %v", flight.Properties)
+	}
+	if d := flight.Properties["distance_km"].(float64); math.Abs(d-5540) > 10 {
+		t.Errorf("Expected about 5540 km, got %v", d)
+	}
+	if len(flight.Lines) != 1 {
+		t.Fatalf("Expected one line, got %d", len(flight.Lines))
+	}
+	line := flight.Lines[0]
+	if line[0] != (Position{-73.7789, 40.6398}) || line[len(line)-1] != (Position{-0.4619, 51.4706}) || len(line) < 40 {
+		t.Errorf("Unexpected route with %d positions from %v to %v", len(line), line[0], line[len(line)-1])
+	}
+	// The great circle goes further north than either airport.
+	if mid := line[len(line)/2]; mid.Latitude < 52 {
+		t.Errorf("Expected a great-circle route, got %v at the middle", mid)
+	}
+
+	// The flight over the Pacific is split at the antimeridian.
+	pacific := c.Features[3]
+	if len(pacific.Lines) != 2 {
+		t.Fatalf("Expected two lines, got %d", len(pacific.Lines))
+	}
+	west, east := pacific.Lines[0], pacific.Lines[1]
+	if end, start := west[len(west)-1], east[0]; end.Longitude != 180 || start.Longitude != -180 || end.Latitude != start.Latitude {
+		t.Errorf("Unexpected split at %v, %v", end, start)
+	}
+
+	hotel, dinner := c.Features[1], c.Features[2]
+	if hotel.Name != "The Hotel" || *hotel.Point != (Position{-0.12, 51.5}) || hotel.Properties["address"] != "1 Main St, London" ||
+		hotel.Properties["end"] != "2011-11-01T11:00:00Z" {
+		t.Errorf("Unexpected hotel: %+v", hotel)
+	}
+	if dinner.Properties["cuisine"] != "Sushi" || dinner.Properties["address"] != "Tokyo, JP" {
+		t.Errorf("Unexpected restaurant: %+v", dinner)
+	}
+}
+
+func TestAddRange(t *testing.T) {
+	it := testItinerary(t)
+	var c Collection
+	c.AddRange(it, tripit.Date{Year: 2011, Month: 10, Day: 31}, tripit.Date{Year: 2011, Month: 11, Day: 4})
+	var names []string
+	for _, f := range c.Features {
+		names = append(names, f.Name)
+	}
+	// The hotel stay overlaps the range; the flights and the directions, which have no time, do not.
+	if strings.Join(names, ",") != "The Hotel,Dinner" {
+		t.Errorf("Unexpected features: %v", names)
+	}
+
+	c = Collection{}
+	c.AddRange(it, tripit.Date{Year: 2011, Month: 11, Day: 5}, tripit.Date{})
+	if len(c.Features) != 1 || c.Features[0].Name != "XA200 NRT-SFO" {
+		t.Errorf("Unexpected features: %+v", c.Features)
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	var c Collection
+	c.AddItinerary(testItinerary(t))
+	var buf bytes.Buffer
+	if err := c.WriteGeoJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var fc struct {
+		Type     string
+		Features []struct {
+			Type     string
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+			Properties map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 5 {
+		t.Fatalf("Unexpected collection: %s", buf.String())
+	}
+	var geometries []string
+	for _, f := range fc.Features {
+		if f.Type != "Feature" || f.Properties["type"] == nil {
+			t.Errorf("Unexpected feature: %+v", f)
+		}
+		geometries = append(geometries, f.Geometry.Type)
+	}
+	if strings.Join(geometries, ",") != "LineString,Point,Point,MultiLineString,LineString" {
+		t.Errorf("Unexpected geometries: %v", geometries)
+	}
+	if s := string(fc.Features[1].Geometry.Coordinates); s != "[-0.12,51.5]" {
+		t.Errorf("Expected longitude first, got %s", s)
+	}
+
+	// An empty collection has an empty list of features.
+	buf.Reset()
+	if err := new(Collection).WriteGeoJSON(&buf); err != nil || buf.String() != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("Unexpected empty collection %s, %v", buf.String(), err)
+	}
+}
+
+func TestWriteKML(t *testing.T) {
+	c := Collection{Name: "Around the world"}
+	c.AddItinerary(testItinerary(t))
+	var buf bytes.Buffer
+	if err := c.WriteKML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2">`) {
+		t.Errorf("Unexpected KML header: %s", buf.String()[:100])
+	}
+	var doc kmlDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	pms := doc.Document.Placemarks
+	if doc.Document.Name != "Around the world" || len(pms) != 5 {
+		t.Fatalf("Unexpected document: %s", buf.String())
+	}
+	if pms[0].LineString == nil || pms[0].LineString.Tessellate != 1 || !strings.HasPrefix(pms[0].LineString.Coordinates, "-73.7789,40.6398 ") {
+		t.Errorf("Unexpected flight: %+v", pms[0])
+	}
+	if pms[1].Point == nil || pms[1].Point.Coordinates != "-0.12,51.5" {
+		t.Errorf("Unexpected hotel: %+v", pms[1])
+	}
+	if pms[3].MultiGeometry == nil || len(pms[3].MultiGeometry.LineStrings) != 2 {
+		t.Errorf("Unexpected flight: %+v", pms[3])
+	}
+	found := false
+	for _, d := range pms[2].Data {
+		found = found || d.Name == "cuisine" && d.Value == "Sushi"
+	}
+	if !found {
+		t.Errorf("Expected the cuisine in %+v", pms[2].Data)
+	}
+}
+
+func TestNewFeatureMismatch(t *testing.T) {
+	// A flight segment with an object that is not an air object has no feature.
+	seg := &tripit.AirSegment{StartAirportLatitude: 40.6398, StartAirportLongitude: -73.7789, EndAirportLatitude: 51.4706, EndAirportLongitude: -0.4619}
+	if f := newFeature(&tripit.ItineraryItem{Segment: seg, Object: &tripit.RailObject{}}); f != nil {
+		t.Errorf("Expected no feature, got %+v", f)
+	}
+}
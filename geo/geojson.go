@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// geoJSONCollection is a GeoJSON FeatureCollection.
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// geoJSONFeature is a GeoJSON Feature.
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONGeometry is a Point, LineString or MultiLineString geometry.
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// coordinates returns the GeoJSON position of p, longitude first.
+func (p Position) coordinates() [2]float64 {
+	return [2]float64{p.Longitude, p.Latitude}
+}
+
+// geometry returns the GeoJSON geometry of the feature.
+func (f *Feature) geometry() geoJSONGeometry {
+	if f.Point != nil {
+		return geoJSONGeometry{"Point", f.Point.coordinates()}
+	}
+	lines := make([][][2]float64, len(f.Lines))
+	for i, l := range f.Lines {
+		lines[i] = make([][2]float64, len(l))
+		for j, p := range l {
+			lines[i][j] = p.coordinates()
+		}
+	}
+	if len(lines) == 1 {
+		return geoJSONGeometry{"LineString", lines[0]}
+	}
+	return geoJSONGeometry{"MultiLineString", lines}
+}
+
+// MarshalJSON encodes the feature as a GeoJSON Feature.
+func (f *Feature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.geoJSON())
+}
+
+// geoJSON returns the GeoJSON Feature for f. Properties are never null.
+func (f *Feature) geoJSON() geoJSONFeature {
+	props := f.Properties
+	if props == nil {
+		props = make(map[string]interface{})
+	}
+	return geoJSONFeature{Type: "Feature", Geometry: f.geometry(), Properties: props}
+}
+
+// MarshalJSON encodes the collection as a GeoJSON FeatureCollection.
+func (c *Collection) MarshalJSON() ([]byte, error) {
+	fc := geoJSONCollection{Type: "FeatureCollection", Features: make([]geoJSONFeature, 0, len(c.Features))}
+	for _, f := range c.Features {
+		fc.Features = append(fc.Features, f.geoJSON())
+	}
+	return json.Marshal(fc)
+}
+
+// WriteGeoJSON writes the collection as a GeoJSON FeatureCollection.
+func (c *Collection) WriteGeoJSON(w io.Writer) error {
+	b, err := c.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
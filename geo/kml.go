@@ -0,0 +1,109 @@
+package geo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kmlNamespace is the namespace of KML 2.2 documents.
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// kmlDocument is a KML document.
+type kmlDocument struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Document struct {
+		Name       string         `xml:"name,omitempty"`
+		Placemarks []kmlPlacemark `xml:"Placemark"`
+	}
+}
+
+// kmlPlacemark is a feature in a KML document.
+type kmlPlacemark struct {
+	Name          string         `xml:"name,omitempty"`
+	Data          []kmlData      `xml:"ExtendedData>Data,omitempty"`
+	Point         *kmlPoint      `xml:"Point,omitempty"`
+	LineString    *kmlLineString `xml:"LineString,omitempty"`
+	MultiGeometry *kmlMultiLine  `xml:"MultiGeometry,omitempty"`
+}
+
+// kmlData is a property of a placemark.
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlPoint is a Point geometry.
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// kmlLineString is a LineString geometry. Tessellate makes viewers draw the line along
+// the ground rather than through it.
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+// kmlMultiLine is a MultiGeometry of LineStrings.
+type kmlMultiLine struct {
+	LineStrings []kmlLineString `xml:"LineString"`
+}
+
+// kmlCoordinates formats positions as a KML coordinates value.
+func kmlCoordinates(ps ...Position) string {
+	s := make([]string, len(ps))
+	for i, p := range ps {
+		s[i] = strconv.FormatFloat(p.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(p.Latitude, 'f', -1, 64)
+	}
+	return strings.Join(s, " ")
+}
+
+// kml returns the placemark for the feature, with its properties sorted by name.
+func (f *Feature) kml() kmlPlacemark {
+	pm := kmlPlacemark{Name: f.Name}
+	names := make([]string, 0, len(f.Properties))
+	for k := range f.Properties {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		pm.Data = append(pm.Data, kmlData{k, fmt.Sprint(f.Properties[k])})
+	}
+	switch {
+	case f.Point != nil:
+		pm.Point = &kmlPoint{kmlCoordinates(*f.Point)}
+	case len(f.Lines) == 1:
+		pm.LineString = &kmlLineString{1, kmlCoordinates(f.Lines[0]...)}
+	case len(f.Lines) > 1:
+		pm.MultiGeometry = new(kmlMultiLine)
+		for _, l := range f.Lines {
+			pm.MultiGeometry.LineStrings = append(pm.MultiGeometry.LineStrings, kmlLineString{1, kmlCoordinates(l...)})
+		}
+	}
+	return pm
+}
+
+// WriteKML writes the collection as a KML document with a placemark for each feature.
+// The properties of the features are written as ExtendedData.
+func (c *Collection) WriteKML(w io.Writer) error {
+	doc := kmlDocument{Xmlns: kmlNamespace}
+	doc.Document.Name = c.Name
+	for _, f := range c.Features {
+		doc.Document.Placemarks = append(doc.Document.Placemarks, f.kml())
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
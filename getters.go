@@ -0,0 +1,125 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Object types that can be retrieved with Get but not created
+const (
+	ObjectTypePointsProgram = "points_program"
+	ObjectTypeProfile       = "profile"
+)
+
+// ErrNotFound is returned by the typed getters when the response from TripIt does not
+// contain the requested object.
+var ErrNotFound = errors.New("tripit: object not found")
+
+// getObject gets an object and uses pick to extract it from the response.
+func getObject[T any](ctx context.Context, t *TripIt, objectType string, objectId uint, pick func(*Response) *T) (*T, error) {
+	resp, err := t.GetContext(ctx, objectType, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if v := pick(resp); v != nil {
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w: %s %d", ErrNotFound, objectType, objectId)
+}
+
+// first returns the first non-nil element of v, or nil.
+func first[T any](v []*T) *T {
+	for _, p := range v {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// firstValue returns a pointer to the first element of v, or nil.
+func firstValue[T any](v []T) *T {
+	if len(v) == 0 {
+		return nil
+	}
+	return &v[0]
+}
+
+// GetTrip gets the trip with the given ID.
+func (t *TripIt) GetTrip(ctx context.Context, id uint) (*Trip, error) {
+	return getObject(ctx, t, ObjectTypeTrip, id, func(r *Response) *Trip { return first(r.Trip) })
+}
+
+// GetAir gets the air object with the given ID.
+func (t *TripIt) GetAir(ctx context.Context, id uint) (*AirObject, error) {
+	return getObject(ctx, t, ObjectTypeAir, id, func(r *Response) *AirObject { return first(r.AirObject) })
+}
+
+// GetLodging gets the lodging object with the given ID.
+func (t *TripIt) GetLodging(ctx context.Context, id uint) (*LodgingObject, error) {
+	return getObject(ctx, t, ObjectTypeLodging, id, func(r *Response) *LodgingObject { return first(r.LodgingObject) })
+}
+
+// GetCar gets the car object with the given ID.
+func (t *TripIt) GetCar(ctx context.Context, id uint) (*CarObject, error) {
+	return getObject(ctx, t, ObjectTypeCar, id, func(r *Response) *CarObject { return first(r.CarObject) })
+}
+
+// GetRail gets the rail object with the given ID.
+func (t *TripIt) GetRail(ctx context.Context, id uint) (*RailObject, error) {
+	return getObject(ctx, t, ObjectTypeRail, id, func(r *Response) *RailObject { return first(r.RailObject) })
+}
+
+// GetCruise gets the cruise object with the given ID.
+func (t *TripIt) GetCruise(ctx context.Context, id uint) (*CruiseObject, error) {
+	return getObject(ctx, t, ObjectTypeCruise, id, func(r *Response) *CruiseObject { return first(r.CruiseObject) })
+}
+
+// GetTransport gets the transport object with the given ID.
+func (t *TripIt) GetTransport(ctx context.Context, id uint) (*TransportObject, error) {
+	return getObject(ctx, t, ObjectTypeTransport, id, func(r *Response) *TransportObject { return first(r.TransportObject) })
+}
+
+// GetActivity gets the activity object with the given ID.
+func (t *TripIt) GetActivity(ctx context.Context, id uint) (*ActivityObject, error) {
+	return getObject(ctx, t, ObjectTypeActivity, id, func(r *Response) *ActivityObject { return first(r.ActivityObject) })
+}
+
+// GetRestaurant gets the restaurant object with the given ID.
+func (t *TripIt) GetRestaurant(ctx context.Context, id uint) (*RestaurantObject, error) {
+	return getObject(ctx, t, ObjectTypeRestaurant, id, func(r *Response) *RestaurantObject { return first(r.RestaurantObject) })
+}
+
+// GetNote gets the note object with the given ID.
+func (t *TripIt) GetNote(ctx context.Context, id uint) (*NoteObject, error) {
+	return getObject(ctx, t, ObjectTypeNote, id, func(r *Response) *NoteObject { return first(r.NoteObject) })
+}
+
+// GetMap gets the map object with the given ID.
+func (t *TripIt) GetMap(ctx context.Context, id uint) (*MapObject, error) {
+	return getObject(ctx, t, ObjectTypeMap, id, func(r *Response) *MapObject { return first(r.MapObject) })
+}
+
+// GetDirections gets the directions object with the given ID.
+func (t *TripIt) GetDirections(ctx context.Context, id uint) (*DirectionsObject, error) {
+	return getObject(ctx, t, ObjectTypeDirections, id, func(r *Response) *DirectionsObject { return first(r.DirectionsObject) })
+}
+
+// GetPointsProgram gets the points program with the given ID. Points programs are
+// only available to TripIt Pro users.
+func (t *TripIt) GetPointsProgram(ctx context.Context, id uint) (*PointsProgram, error) {
+	return getObject(ctx, t, ObjectTypePointsProgram, id, func(r *Response) *PointsProgram { return firstValue(r.PointsProgram) })
+}
+
+// GetProfile gets the profile of the authenticated user.
+func (t *TripIt) GetProfile(ctx context.Context) (*Profile, error) {
+	resp, err := t.get(ctx, ObjectTypeProfile)
+	if err != nil {
+		return nil, err
+	}
+	if p := firstValue(resp.Profile); p != nil {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, ObjectTypeProfile)
+}
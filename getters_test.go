@@ -0,0 +1,40 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/get/trip/id/1/format/json":
+			w.Write([]byte(`{"Trip":{"id":"1","display_name":"Cancun"}}`))
+		case "/v1/get/profile/format/json":
+			w.Write([]byte(`{"Profile":{"@attributes":{"ref":"abc"},"screen_name":"traveler"}}`))
+		default:
+			w.Write([]byte(`{"timestamp":"1306543281"}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+	ctx := context.Background()
+
+	trip, err := c.GetTrip(ctx, 1)
+	if err != nil || trip.DisplayName != "Cancun" {
+		t.Errorf("Unexpected trip: %v, %v", trip, err)
+	}
+	p, err := c.GetProfile(ctx)
+	if err != nil || p.ScreenName != "traveler" {
+		t.Errorf("Unexpected profile: %v, %v", p, err)
+	}
+	if _, err := c.GetAir(ctx, 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := c.GetPointsProgram(ctx, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/ancientlore/go-tripit
 
-go 1.19
+go 1.24
@@ -2,15 +2,18 @@ package tripit
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
+	"math"
 	"net/http"
 	"net/url"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 )
 
 // TripIt API information
@@ -19,6 +22,13 @@ const (
 	ApiVersion = "v1"
 )
 
+// DefaultMaxBodySize is the largest response body the client reads unless changed with
+// WithMaxBodySize.
+const DefaultMaxBodySize = 64 << 20
+
+// ErrBodyTooLarge is returned when a response body is larger than the client's maximum body size.
+var ErrBodyTooLarge = errors.New("tripit: response body too large")
+
 // List objects
 const (
 	ListTrip          = "trip"
@@ -46,139 +56,297 @@ type Authorizable interface {
 
 // TripIt class to used to communicate with the API.
 type TripIt struct {
-	baseUrl     string
-	version     string
-	httpClient  *http.Client
-	credentials Authorizable
+	baseUrl        string
+	version        string
+	httpClient     *http.Client
+	credentials    Authorizable
+	responseErrors bool
+	retryPolicy    *RetryPolicy
+	limiter        *RateLimiter
+	userAgent      string
+	logger         *log.Logger
+	middleware     []Middleware
+	codec          Codec
+	maxBodySize    int64
 }
 
 // New creates a new TripIt object using the given HTTP client and authorization object.
+// See NewClient for more configuration options.
 func New(apiUrl string, apiVersion string, client *http.Client, creds Authorizable) *TripIt {
-	return &TripIt{apiUrl, apiVersion, client, creds}
+	return &TripIt{baseUrl: apiUrl, version: apiVersion, httpClient: client, credentials: creds, codec: JsonCodec}
 }
 
-// Makes an HTTP request to the TripIt API and returns the response.
-func (t *TripIt) makeRequest(req *http.Request) (*Response, error) {
-	resp, err := t.httpClient.Do(req)
+// SetResponseErrors controls whether a successful response from TripIt that contains
+// Error entries is returned as an *APIError. By default, such responses are returned
+// as-is and the caller must check Response.Error.
+func (t *TripIt) SetResponseErrors(enable bool) {
+	t.responseErrors = enable
+}
+
+// apiRequest describes a call to the TripIt API. Because the OAuth authorization
+// header contains a nonce and timestamp, a new http.Request is built from it for
+// every attempt.
+type apiRequest struct {
+	method      string
+	url         string
+	body        []byte            // request body, if any
+	contentType string            // content type of the body, if any
+	args        map[string]string // additional arguments used in the signature
+	idempotent  bool              // whether the request may be retried by default
+}
+
+// newRequest builds and authorizes an http.Request for the given call.
+func (t *TripIt) newRequest(ctx context.Context, r *apiRequest) (*http.Request, error) {
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
+	t.credentials.Authorize(req, r.args)
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
 	}
+	return req, nil
+}
 
-	// Copy buffer and change @attributes to _attributes since json package doesn't support @
-	buf := new(bytes.Buffer)
-	io.Copy(buf, resp.Body)
-	b := bytes.Replace(buf.Bytes(), []byte("\"@attributes\""), []byte("\"_attributes\""), -1)
+// Makes an HTTP request to the TripIt API and returns the response. Successful responses
+// are decoded as they are read from the response body, up to the maximum body size.
+func (t *TripIt) makeRequest(ctx context.Context, r *apiRequest) (*Response, error) {
+	resp, err := t.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body := t.limitBody(resp.Body)
 
-	// debug logging
-	// f, _ := os.Create("output.json")
-	// defer f.Close()
-	// f.Write(b)
+	if resp.StatusCode != 200 {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: b}
+		result := new(Response)
+		if t.codec.Decode(bytes.NewReader(b), result) == nil {
+			apiErr.Errors = result.Error
+			apiErr.Warnings = result.Warning
+			apiErr.Response = result
+		}
+		return nil, apiErr
+	}
 
 	result := new(Response)
-	err = json.Unmarshal(b, result)
+	err = t.codec.Decode(body, result)
+	if body.remaining < 0 {
+		return nil, ErrBodyTooLarge
+	}
 	if err != nil {
 		return nil, err
 	}
+	// Drain any trailing whitespace so that the connection can be reused.
+	io.Copy(ioutil.Discard, body)
+	result.indexProfiles()
 
-	// debug logging
-	// log.Print(result)
+	if t.responseErrors && len(result.Error) > 0 {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Errors:     result.Error,
+			Warnings:   result.Warning,
+			Response:   result,
+		}
+	}
 
 	return result, nil
 }
 
-// Get gets an Object of the given type and ID, and returns the Response object from TripIt.
-// supports: air, activity, car, cruise, directions, lodging, map, note, rail, restaurant, transport, trip
-func (t *TripIt) Get(objectType string, objectId uint) (*Response, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/get/%s/id/%d/format/json", t.baseUrl, t.version, objectType, objectId), nil)
+// limitBody limits the number of bytes read from a response body to the client's
+// maximum body size.
+func (t *TripIt) limitBody(r io.Reader) *limitedReader {
+	n := t.maxBodySize
+	if n == 0 {
+		n = DefaultMaxBodySize
+	} else if n < 0 {
+		n = math.MaxInt64 - 1
+	}
+	return &limitedReader{r: r, remaining: n}
+}
+
+// limitedReader reads from r until remaining bytes have been read, and returns
+// ErrBodyTooLarge if r has more data. Once the limit is exceeded, remaining is negative.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		l.remaining = -1
+		return n, ErrBodyTooLarge
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// do sends the request using the HTTP client. If the request's context was canceled
+// or its deadline expired, the context's error is returned so that callers can test
+// for it with errors.Is.
+func (t *TripIt) do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.httpClient.Do(req)
+	if t.logger != nil {
+		if err != nil {
+			t.logger.Printf("tripit: %s %s: %v (%v)", req.Method, req.URL, err, time.Since(start))
+		} else {
+			t.logger.Printf("tripit: %s %s: %s (%v)", req.Method, req.URL, resp.Status, time.Since(start))
+		}
+	}
 	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
-	t.credentials.Authorize(req, nil)
-	return t.makeRequest(req)
+	return resp, nil
+}
+
+// Get gets an Object of the given type and ID, and returns the Response object from TripIt.
+// supports: air, activity, car, cruise, directions, lodging, map, note, rail, restaurant, transport, trip
+func (t *TripIt) Get(objectType string, objectId uint) (*Response, error) {
+	return t.GetContext(context.Background(), objectType, objectId)
+}
+
+// GetContext is like Get but uses the given context for the request.
+func (t *TripIt) GetContext(ctx context.Context, objectType string, objectId uint) (*Response, error) {
+	return t.get(ctx, fmt.Sprintf("%s/id/%d", objectType, objectId))
+}
+
+// get gets the object at the given path, relative to the get request.
+func (t *TripIt) get(ctx context.Context, path string) (*Response, error) {
+	return t.makeRequest(ctx, &apiRequest{
+		method:     "GET",
+		url:        fmt.Sprintf("%s/%s/get/%s/format/%s", t.baseUrl, t.version, path, t.codec.Format()),
+		idempotent: true,
+	})
 }
 
 // List lists objects of the given type, filtered by the given filter parameters. Returns
 // the response object from TripIt. To understand filter parameters and which filters
-// can be combined, see the TripIt API documentation.
+// can be combined, see the TripIt API documentation. ListFiltered provides typed filters
+// that are validated before the request is made.
 // supports: trip, object, points_program
 func (t *TripIt) List(objectType string, filterParms map[string]string) (*Response, error) {
-	var x string
-	for p, v := range filterParms {
-		x += fmt.Sprintf("/%s/%s", p, v)
+	return t.ListContext(context.Background(), objectType, filterParms)
+}
+
+// ListContext is like List but uses the given context for the request.
+func (t *TripIt) ListContext(ctx context.Context, objectType string, filterParms map[string]string) (*Response, error) {
+	keys := make([]string, 0, len(filterParms))
+	for p := range filterParms {
+		keys = append(keys, p)
 	}
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/list/%s%s/format/json", t.baseUrl, t.version, objectType, x), nil)
-	if err != nil {
-		return nil, err
+	sort.Strings(keys)
+	var x string
+	for _, p := range keys {
+		x += fmt.Sprintf("/%s/%s", p, url.PathEscape(filterParms[p]))
 	}
-	t.credentials.Authorize(req, nil)
-	return t.makeRequest(req)
+	return t.makeRequest(ctx, &apiRequest{
+		method:     "GET",
+		url:        fmt.Sprintf("%s/%s/list/%s%s/format/%s", t.baseUrl, t.version, objectType, x, t.codec.Format()),
+		idempotent: true,
+	})
 }
 
-// encodeForm encodes form arguments to send to TripIt
-func encodeForm(r *Request) (*bytes.Buffer, map[string]string, error) {
-	b, err := json.Marshal(r)
+// encodeForm encodes form arguments to send to TripIt. The request is sent in a form
+// field named after the codec's format, along with any additional form parameters.
+func encodeForm(c Codec, r *Request, params map[string]string) ([]byte, map[string]string, error) {
+	b, err := c.Encode(r)
 	if err != nil {
 		return nil, nil, err
 	}
 	s := string(b)
 	// s = `{"Trip":{"start_date":"2011-12-09","end_date":"2011-12-27","primary_location":"Cancun, Mexico","display_name":"My Test Trip"}}`
 	m := make(map[string][]string)
-	m["json"] = []string{s}
 	args := make(map[string]string)
-	args["json"] = s
-	return bytes.NewBuffer([]byte(url.Values(m).Encode())), args, nil
+	for k, v := range params {
+		m[k] = []string{v}
+		args[k] = v
+	}
+	m[c.Format()] = []string{s}
+	args[c.Format()] = s
+	return []byte(url.Values(m).Encode()), args, nil
 }
 
 // Create creates an object in TripIt based on the given Request, returning the Response object from TripIt.
 // supports: air, activity, car, cruise, directions, lodging, map, note, rail, restaurant, transport, trip
 func (t *TripIt) Create(r *Request) (*Response, error) {
-	buf, args, err := encodeForm(r)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/create/format/json", t.baseUrl, t.version), ioutil.NopCloser(buf))
+	return t.CreateContext(context.Background(), r)
+}
+
+// CreateContext is like Create but uses the given context for the request.
+func (t *TripIt) CreateContext(ctx context.Context, r *Request) (*Response, error) {
+	return t.postForm(ctx, "create", r, nil)
+}
+
+// postForm posts the Request to the given path, relative to the API version, as a form
+// with any additional form parameters. The form fields are included in the OAuth
+// signature. All calls that send a Request use postForm.
+func (t *TripIt) postForm(ctx context.Context, path string, r *Request, params map[string]string) (*Response, error) {
+	buf, args, err := encodeForm(t.codec, r, params)
 	if err != nil {
 		return nil, err
 	}
-	t.credentials.Authorize(req, args)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Content-Length", strconv.Itoa(buf.Len()))
-	req.ContentLength = int64(buf.Len())
-	return t.makeRequest(req)
+	return t.makeRequest(ctx, &apiRequest{
+		method:      "POST",
+		url:         fmt.Sprintf("%s/%s/%s/format/%s", t.baseUrl, t.version, path, t.codec.Format()),
+		body:        buf,
+		contentType: "application/x-www-form-urlencoded",
+		args:        args,
+	})
 }
 
 // Replace replaces the object of the given type and ID with the new object in the Request. Returns
 // the Response object from TripIt.
 // supports: air, activity, car, cruise, directions, lodging, map, note, rail, restaurant, transport, trip
 func (t *TripIt) Replace(objectType string, objectId uint, r *Request) (*Response, error) {
-	b := new(bytes.Buffer)
-	json := json.NewEncoder(b)
-	err := json.Encode(r)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/replace/%s/id/%d/format/json", t.baseUrl, t.version, objectType, objectId), b)
-	if err != nil {
-		return nil, err
-	}
-	t.credentials.Authorize(req, nil)
-	return t.makeRequest(req)
+	return t.ReplaceContext(context.Background(), objectType, objectId, r)
+}
+
+// ReplaceContext is like Replace but uses the given context for the request.
+func (t *TripIt) ReplaceContext(ctx context.Context, objectType string, objectId uint, r *Request) (*Response, error) {
+	return t.postForm(ctx, fmt.Sprintf("replace/%s/id/%d", objectType, objectId), r, nil)
 }
 
 // Delete deletes the object of the given type and ID from TripIt, and returns the Response object
 // from TripIt.
 // supports: air, activity, car, cruise, directions, lodging, map, note, rail, restaurant, transport, trip
 func (t *TripIt) Delete(objectType string, objectId uint) (*Response, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/delete/%s/id/%d/format/json", t.baseUrl, t.version, objectType, objectId), nil)
-	if err != nil {
-		return nil, err
-	}
-	t.credentials.Authorize(req, nil)
-	return t.makeRequest(req)
+	return t.DeleteContext(context.Background(), objectType, objectId)
+}
+
+// DeleteContext is like Delete but uses the given context for the request.
+func (t *TripIt) DeleteContext(ctx context.Context, objectType string, objectId uint) (*Response, error) {
+	return t.makeRequest(ctx, &apiRequest{
+		method: "GET",
+		url:    fmt.Sprintf("%s/%s/delete/%s/id/%d/format/%s", t.baseUrl, t.version, objectType, objectId, t.codec.Format()),
+	})
 }
 
 // GetRequestToken is step 1 of the OAuth process. The function returns the token and secret
@@ -186,40 +354,39 @@ func (t *TripIt) Delete(objectType string, objectId uint) (*Response, error) {
 // is not the permanent one - if the user aborts the authentication process, these can
 // be discarded.
 func (t *TripIt) GetRequestToken() (map[string]string, error) {
-	req, err := http.NewRequest("GET", t.baseUrl+UrlObtainRequestToken, nil)
-	if err != nil {
-		return nil, err
-	}
-	t.credentials.Authorize(req, nil)
-	resp, err := t.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
-	return parseQS(resp.Body)
+	return t.GetRequestTokenContext(context.Background())
+}
+
+// GetRequestTokenContext is like GetRequestToken but uses the given context for the request.
+func (t *TripIt) GetRequestTokenContext(ctx context.Context) (map[string]string, error) {
+	return t.getToken(ctx, UrlObtainRequestToken, true)
 }
 
 // GetAccessToken gets the final OAuth token and token secret for an
 // authenticated user. These should be saved with the user's ID for
 // future used of the API on the user's behalf.
 func (t *TripIt) GetAccessToken() (map[string]string, error) {
-	req, err := http.NewRequest("GET", t.baseUrl+UrlObtainAccessToken, nil)
-	if err != nil {
-		return nil, err
-	}
-	t.credentials.Authorize(req, nil)
-	resp, err := t.httpClient.Do(req)
+	return t.GetAccessTokenContext(context.Background())
+}
+
+// GetAccessTokenContext is like GetAccessToken but uses the given context for the request.
+func (t *TripIt) GetAccessTokenContext(ctx context.Context) (map[string]string, error) {
+	return t.getToken(ctx, UrlObtainAccessToken, false)
+}
+
+// getToken requests an OAuth token from the given path and parses the result.
+func (t *TripIt) getToken(ctx context.Context, path string, idempotent bool) (map[string]string, error) {
+	resp, err := t.send(ctx, &apiRequest{method: "GET", url: t.baseUrl + path, idempotent: idempotent})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	body := t.limitBody(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
+		b, _ := ioutil.ReadAll(body)
+		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: b}
 	}
-	return parseQS(resp.Body)
+	return parseQS(body)
 }
 
 // parseQS parses the query string in the body and returns a simple map of the values.
@@ -238,5 +405,3 @@ func parseQS(body io.Reader) (map[string]string, error) {
 	}
 	return result, nil
 }
-
-// @TODO Add CRS elements
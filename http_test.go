@@ -0,0 +1,183 @@
+package tripit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContextCanceled(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	c := New(srv.URL, ApiVersion, srv.Client(), &WebAuthCredential{"user@site.com", "password"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	_, err := c.GetContext(ctx, ObjectTypeTrip, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.GetRequestTokenContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/get/trip/id/1/format/json" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Error":{"code":"404","detailed_error_code":"106.1","description":"Not found","entity_type":"Trip"},"timestamp":"1306543281"}`))
+			return
+		}
+		w.Write([]byte(`{"Error":[{"code":"400","description":"Bad"}],"Warning":{"description":"Careful"}}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, ApiVersion, srv.Client(), &WebAuthCredential{"user@site.com", "password"})
+	_, err := c.Get(ObjectTypeTrip, 1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || len(apiErr.Body) == 0 {
+		t.Errorf("Unexpected status or body: %d %q", apiErr.StatusCode, apiErr.Body)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0].DetailedErrorCode != 106.1 || apiErr.Errors[0].EntityType != "Trip" {
+		t.Errorf("Unexpected errors: %v", apiErr.Errors)
+	}
+	var tErr *Error
+	if !errors.As(err, &tErr) || tErr.Code != 404 {
+		t.Errorf("Expected *Error with code 404, got %v", tErr)
+	}
+
+	resp, err := c.Get(ObjectTypeTrip, 2)
+	if err != nil || len(resp.Error) != 1 {
+		t.Errorf("Expected response with errors, got %v, %v", resp, err)
+	}
+
+	c.SetResponseErrors(true)
+	_, err = c.Get(ObjectTypeTrip, 2)
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusOK || len(apiErr.Errors) != 1 || len(apiErr.Warnings) != 1 || apiErr.Response == nil {
+		t.Errorf("Unexpected API error: %+v", apiErr)
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	body := `{"Trip":{"id":"1","display_name":"Cancun"},"Profile":{"@attributes":{"ref":"abc"},"screen_name":"@attributes"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+	resp, err := c.Get(ObjectTypeTrip, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Profile) != 1 || resp.Profile[0].Attributes.Ref != "abc" || resp.Profile[0].ScreenName != "@attributes" || !resp.indexCurrent() {
+		t.Errorf("Unexpected profiles: %+v", resp.Profile)
+	}
+
+	for _, n := range []int64{10, int64(len(body)) - 1} {
+		c = NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()), WithMaxBodySize(n))
+		if _, err := c.Get(ObjectTypeTrip, 1); !errors.Is(err, ErrBodyTooLarge) {
+			t.Errorf("Expected ErrBodyTooLarge for limit %d, got %v", n, err)
+		}
+	}
+	for _, n := range []int64{int64(len(body)), -1} {
+		c = NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()), WithMaxBodySize(n))
+		if _, err := c.Get(ObjectTypeTrip, 1); err != nil {
+			t.Errorf("Unexpected error for limit %d: %v", n, err)
+		}
+	}
+}
+
+// checkSignedForm verifies that the request's OAuth signature covers its form fields and
+// query parameters, and that posts are form posts. It returns false if the form cannot be
+// read. It runs in the handler's goroutine, so it reports errors with t.Error rather than
+// t.Fatal.
+func checkSignedForm(t *testing.T, c *OAuthConsumerCredential, r *http.Request) bool {
+	if ct := r.Header.Get("Content-Type"); r.Method == "POST" && ct != "application/x-www-form-urlencoded" {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+	if err := r.ParseForm(); err != nil {
+		t.Error(err)
+		return false
+	}
+	params := make(map[string]string)
+	for k, v := range r.Form {
+		params[k] = v[0]
+	}
+	var sig string
+	for _, kv := range strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "OAuth "), ",") {
+		k, v, _ := strings.Cut(kv, "=")
+		v, _ = url.QueryUnescape(strings.Trim(v, `"`))
+		switch k {
+		case "realm":
+		case "oauth_signature":
+			sig = v
+		default:
+			params[k] = v
+		}
+	}
+	if expected := c.generateSignature(r.Method, "http://"+r.Host+r.URL.Path, params); sig != expected {
+		t.Errorf("Invalid signature %q, expected %q", sig, expected)
+	}
+	return true
+}
+
+func TestWriteRequests(t *testing.T) {
+	cred := NewOAuth3LeggedCredential("key", "secret", "token", "tokensecret")
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Method != "POST" {
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+		if !checkSignedForm(t, cred, r) {
+			return
+		}
+		var req Request
+		if err := json.Unmarshal([]byte(r.PostFormValue("json")), &req); err != nil || req.Trip == nil {
+			t.Errorf("Unexpected json field %q: %v", r.PostFormValue("json"), err)
+		}
+		w.Write([]byte(`{"Trip":{"id":"1","display_name":"Cancun"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(cred, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+	r := &Request{Trip: &Trip{DisplayName: "Cancun & Tulum", StartDate: Date{2011, 12, 9}}}
+	if _, err := c.Create(r); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.Replace(ObjectTypeTrip, 1, r); err != nil {
+		t.Error(err)
+	}
+	if len(paths) != 2 || paths[0] != "/v1/create/format/json" || paths[1] != "/v1/replace/trip/id/1/format/json" {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+}
@@ -0,0 +1,396 @@
+// Package ical exports TripIt trips and reservations as iCalendar (RFC 5545) data, and
+// imports calendar events as TripIt activities and restaurant reservations.
+package ical
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ancientlore/go-tripit"
+)
+
+// Defaults used by NewCalendar
+const (
+	DefaultProdID = "-//ancientlore//go-tripit//EN"
+	DefaultDomain = "tripit.com"
+)
+
+// Event is a calendar event.
+type Event struct {
+	UID         string    // unique and stable identifier
+	Summary     string    // title of the event
+	Description string    // optional
+	Location    string    // optional
+	URL         string    // optional
+	Start       time.Time // start of the event, in the time zone where it takes place
+	End         time.Time // optional, non-inclusive end of the event
+	AllDay      bool      // Start and End are dates; End is the day after the last day
+	Latitude    float64   // optional, GEO latitude
+	Longitude   float64   // optional, GEO longitude
+}
+
+// Calendar is a list of events that can be written as an iCalendar object.
+type Calendar struct {
+	ProdID string    // product identifier
+	Name   string    // optional, calendar name shown by clients
+	Domain string    // domain used in the UIDs of events added from itineraries
+	Stamp  time.Time // DTSTAMP of the events; zero uses the current time
+	Events []*Event
+}
+
+// NewCalendar creates an empty calendar with the default product identifier and domain.
+func NewCalendar() *Calendar {
+	return &Calendar{ProdID: DefaultProdID, Domain: DefaultDomain}
+}
+
+// AddItinerary adds an all-day event for the trip and an event for each reservation and
+// segment in the itinerary. Segments of air, rail, transport and cruise objects become
+// separate events. Notes, maps, directions and items without a time are not added.
+func (c *Calendar) AddItinerary(it *tripit.Itinerary) {
+	if t := it.Trip; t != nil && !t.StartDate.IsZero() {
+		end := t.EndDate
+		if end.IsZero() || end.Before(t.StartDate) {
+			end = t.StartDate
+		}
+		e := &Event{
+			UID:         c.uid(tripit.ObjectTypeTrip, t.Id, t.DisplayName, t.StartDate.String()),
+			Summary:     t.DisplayName,
+			Description: t.Description,
+			Location:    t.PrimaryLocation,
+			Start:       t.StartDate.In(time.UTC),
+			End:         end.AddDays(1).In(time.UTC),
+			AllDay:      true,
+		}
+		if a := t.PrimaryLocationAddress; a != nil {
+			e.Latitude, e.Longitude = a.Latitude, a.Longitude
+		}
+		c.Events = append(c.Events, e)
+	}
+	for i := range it.Items {
+		item := &it.Items[i]
+		o, ok := item.Object.(tripit.Object)
+		if !ok || item.Time.IsZero() {
+			continue
+		}
+		c.Events = append(c.Events, c.itemEvent(o, item))
+	}
+}
+
+// itemEvent returns the event for an object or one of its segments.
+func (c *Calendar) itemEvent(o tripit.Object, item *tripit.ItineraryItem) *Event {
+	b := o.Booking()
+	e := &Event{Summary: o.Name(), Start: item.Time, URL: b.SupplierUrl}
+	if e.URL == "" {
+		e.URL = b.SiteUrl
+	}
+	var desc []string
+	var addr *tripit.Address
+	var end *tripit.DateTime
+	segId := ""
+	switch s := item.Segment.(type) {
+	case *tripit.AirSegment:
+		airline := s.MarketingAirlineCode
+		if airline == "" {
+			airline = s.MarketingAirline
+		}
+		e.Summary = join(" ", "Flight", airline+s.MarketingFlightNumber, route(s.StartAirportCode, s.EndAirportCode))
+		e.Location = join(" ", s.StartCityName, parens(s.StartAirportCode))
+		e.Latitude, e.Longitude = s.StartAirportLatitude, s.StartAirportLongitude
+		desc = append(desc, label("Departure terminal", s.StartTerminal), label("Seats", s.Seats))
+		end, segId = s.EndDateTime, s.Id
+	case *tripit.RailSegment:
+		e.Summary = join(" ", "Train", s.CarrierName, s.TrainNumber, route(s.StartStationName, s.EndStationName))
+		e.Location, addr = s.StartStationName, s.StartStationAddress
+		desc = append(desc, label("Confirmation", s.ConfirmationNum), label("Coach", s.CoachNumber), label("Seats", s.Seats))
+		end, segId = s.EndDateTime, s.Id
+	case *tripit.TransportSegment:
+		e.Summary = join(" ", o.Name(), route(s.StartLocationName, s.EndLocationName))
+		e.Location, addr = s.StartLocationName, s.StartLocationAddress
+		end, segId = s.EndDateTime, s.Id
+	case *tripit.CruiseSegment:
+		e.Summary = join(" - ", o.Name(), s.LocationName)
+		e.Location, addr = s.LocationName, s.LocationAddress
+		end, segId = s.EndDateTime, s.Id
+	case nil:
+		e.Location, addr = objectLocation(o)
+		if t, err := o.End(); err == nil {
+			e.End = t
+		}
+	}
+	if end != nil {
+		if t, err := end.GetTime(); err == nil {
+			e.End = t
+		}
+	}
+	if e.Summary == "" {
+		e.Summary = e.Location
+	}
+	if item.Segment != nil && segId == "" {
+		segId = item.Time.UTC().Format("20060102T150405Z")
+	}
+	e.UID = c.uid(o.ObjectType(), join("-", o.ID(), segId), e.Summary, item.Time.UTC().String())
+	if addr != nil {
+		if a := formatAddress(addr); a != "" {
+			e.Location = join(", ", e.Location, a)
+		}
+		if addr.Latitude != 0 || addr.Longitude != 0 {
+			e.Latitude, e.Longitude = addr.Latitude, addr.Longitude
+		}
+	}
+	desc = append([]string{
+		label("Confirmation", b.SiteConfNum),
+		label("Supplier confirmation", b.SupplierConfNum),
+		label("Record locator", b.RecordLocator),
+		label("Supplier", b.SupplierName),
+		label("Phone", b.SupplierPhone),
+	}, desc...)
+	desc = append(desc, b.Notes)
+	e.Description = join("\n", desc...)
+	return e
+}
+
+// objectLocation returns the location name and address of an object without segments.
+func objectLocation(o tripit.Object) (string, *tripit.Address) {
+	switch v := o.(type) {
+	case *tripit.LodgingObject:
+		return v.SupplierName, v.Address
+	case *tripit.CarObject:
+		return v.StartLocationName, v.StartLocationAddress
+	case *tripit.ActivityObject:
+		return v.LocationName, v.Address
+	case *tripit.RestaurantObject:
+		return v.SupplierName, v.Address
+	}
+	return "", nil
+}
+
+// uid returns a stable UID for an object. If the object has no ID, the UID is derived
+// from the other values.
+func (c *Calendar) uid(objectType, id string, fallback ...string) string {
+	domain := c.Domain
+	if domain == "" {
+		domain = DefaultDomain
+	}
+	if id == "" {
+		h := sha1.Sum([]byte(strings.Join(fallback, "\x00")))
+		id = hex.EncodeToString(h[:10])
+	}
+	return fmt.Sprintf("%s-%s@%s", objectType, id, domain)
+}
+
+// WriteTo writes the calendar as an iCalendar object, including a VTIMEZONE component
+// for each time zone used by the events.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	lw := &lineWriter{w: bufio.NewWriter(w)}
+	stamp := c.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	prodId := c.ProdID
+	if prodId == "" {
+		prodId = DefaultProdID
+	}
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + prodId)
+	lw.line("CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	for _, z := range c.zones() {
+		writeTimezone(lw, z.loc, z.from, z.to)
+	}
+	for _, e := range c.Events {
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + e.UID)
+		lw.line("DTSTAMP:" + utcTime(stamp))
+		if e.AllDay {
+			lw.line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+			if e.End.After(e.Start) {
+				lw.line("DTEND;VALUE=DATE:" + e.End.Format("20060102"))
+			}
+		} else {
+			lw.line("DTSTART" + dateTime(e.Start))
+			if e.End.After(e.Start) {
+				lw.line("DTEND" + dateTime(e.End))
+			}
+		}
+		lw.line("SUMMARY:" + escape(e.Summary))
+		if e.Location != "" {
+			lw.line("LOCATION:" + escape(e.Location))
+		}
+		if e.Latitude != 0 || e.Longitude != 0 {
+			lw.line("GEO:" + strconv.FormatFloat(e.Latitude, 'f', -1, 64) + ";" + strconv.FormatFloat(e.Longitude, 'f', -1, 64))
+		}
+		if e.Description != "" {
+			lw.line("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.URL != "" {
+			lw.line("URL:" + e.URL)
+		}
+		lw.line("END:VEVENT")
+	}
+	lw.line("END:VCALENDAR")
+	if lw.err == nil {
+		lw.err = lw.w.Flush()
+	}
+	return lw.n, lw.err
+}
+
+// zone is a time zone used by the calendar, with the range of times it is used for.
+type zone struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// zones returns the time zones used by the events, sorted by name.
+func (c *Calendar) zones() []zone {
+	m := make(map[string]*zone)
+	add := func(t time.Time) {
+		if t.IsZero() || tzid(t.Location()) == "" {
+			return
+		}
+		name := t.Location().String()
+		if z, ok := m[name]; ok {
+			if t.Before(z.from) {
+				z.from = t
+			}
+			if t.After(z.to) {
+				z.to = t
+			}
+			return
+		}
+		m[name] = &zone{t.Location(), t, t}
+	}
+	for _, e := range c.Events {
+		if !e.AllDay {
+			add(e.Start)
+			add(e.End)
+		}
+	}
+	result := make([]zone, 0, len(m))
+	for _, z := range m {
+		result = append(result, *z)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].loc.String() < result[j].loc.String()
+	})
+	return result
+}
+
+// tzid returns the TZID for a location, or an empty string if times in the location are
+// written in UTC. Only IANA time zones that can be loaded get a TZID.
+func tzid(loc *time.Location) string {
+	name := loc.String()
+	if name == "UTC" || name == "Local" || !strings.Contains(name, "/") {
+		return ""
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// dateTime returns the parameters and value of a DATE-TIME property, using the TZID of
+// the time's location or UTC.
+func dateTime(t time.Time) string {
+	if id := tzid(t.Location()); id != "" {
+		return ";TZID=" + id + ":" + t.Format("20060102T150405")
+	}
+	return ":" + utcTime(t)
+}
+
+// utcTime formats t as a UTC DATE-TIME value.
+func utcTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// join joins the non-empty values with sep.
+func join(sep string, values ...string) string {
+	var s []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			s = append(s, v)
+		}
+	}
+	return strings.Join(s, sep)
+}
+
+// label returns "name: value", or an empty string if value is empty.
+func label(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	return name + ": " + value
+}
+
+// parens returns "(s)", or an empty string if s is empty.
+func parens(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "(" + s + ")"
+}
+
+// route returns "from to to" if both are known.
+func route(from, to string) string {
+	if from == "" || to == "" {
+		return join(" ", from, to)
+	}
+	return from + " to " + to
+}
+
+// formatAddress returns a one-line address.
+func formatAddress(a *tripit.Address) string {
+	if a.Address != "" {
+		return a.Address
+	}
+	return join(", ", a.Addr1, a.Addr2, a.City, join(" ", a.State, a.Zip), a.Country)
+}
+
+// lineWriter writes content lines, folding them at 75 octets and ending them with CRLF.
+type lineWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+// line writes a content line. Continuation lines start with a space, which counts
+// towards their length.
+func (lw *lineWriter) line(s string) {
+	max := 75
+	for len(s) > max {
+		i := max
+		// Don't split a UTF-8 sequence.
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		lw.write(s[:i] + "\r\n ")
+		s = s[i:]
+		max = 74
+	}
+	lw.write(s + "\r\n")
+}
+
+// write writes s, keeping the first error.
+func (lw *lineWriter) write(s string) {
+	if lw.err != nil {
+		return
+	}
+	n, err := lw.w.WriteString(s)
+	lw.n += int64(n)
+	lw.err = err
+}
@@ -0,0 +1,283 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ancientlore/go-tripit"
+)
+
+const tripJSON = `{
+"Trip":{"id":"1","display_name":"London, Paris","start_date":"2011-10-28","end_date":"2011-11-02","primary_location":"London, United Kingdom"},
+"AirObject":{"id":"10","trip_id":"1","booking_site_conf_num":"ABC123","record_locator":"XYZ789","supplier_name":"Example Air","Segment":[
+	{"id":"11","StartDateTime":{"date":"2011-10-28","time":"18:00:00","timezone":"America/New_York"},"EndDateTime":{"date":"2011-10-29","time":"06:30:00","timezone":"Europe/London"},
+	 "start_airport_code":"JFK","start_city_name":"New York, NY","end_airport_code":"LHR","marketing_airline_code":"XA","marketing_flight_number":"100","seats":"12A"},
+	{"id":"12","StartDateTime":{"date":"2011-11-02","time":"09:00:00","timezone":"Europe/Paris"},"EndDateTime":{"date":"2011-11-02","time":"11:15:00","timezone":"America/New_York"},
+	 "start_airport_code":"CDG","start_city_name":"Paris","end_airport_code":"JFK","marketing_airline_code":"XA","marketing_flight_number":"101"}
+]},
+"LodgingObject":{"id":"20","trip_id":"1","supplier_name":"The Hotel; London","supplier_conf_num":"H-55","notes":"Late check-in, quiet room\nNon-smoking",
+	"StartDateTime":{"date":"2011-10-29","time":"15:00:00","timezone":"Europe/London"},"EndDateTime":{"date":"2011-10-31","time":"11:00:00","timezone":"Europe/London"},
+	"Address":{"addr1":"1 Long Street Name That Makes The Location Line Long Enough To Need Folding","city":"London","country":"GB","latitude":"51.5","longitude":"-0.12"}},
+"RestaurantObject":{"trip_id":"1","supplier_name":"Café à la crème brûlée, très très très très très très très très bien",
+	"DateTime":{"date":"2011-10-31","time":"20:00:00","utc_offset":"+01:00"}},
+"NoteObject":{"id":"40","trip_id":"1","DateTime":{"date":"2011-10-30","time":"10:00:00","timezone":"Europe/London"}}
+}`
+
+// testCalendar returns a calendar for the test trip.
+func testCalendar(t *testing.T) *Calendar {
+	var resp tripit.Response
+	if err := json.Unmarshal([]byte(tripJSON), &resp); err != nil {
+		t.Fatal(err)
+	}
+	its := tripit.NewItineraries(&resp)
+	if len(its) != 1 {
+		t.Fatalf("Expected 1 itinerary, got %d", len(its))
+	}
+	c := NewCalendar()
+	c.Name = "Trips"
+	c.Stamp = time.Date(2011, 10, 1, 12, 0, 0, 0, time.UTC)
+	c.AddItinerary(its[0])
+	return c
+}
+
+func TestAddItinerary(t *testing.T) {
+	c := testCalendar(t)
+	if len(c.Events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(c.Events))
+	}
+	trip, flight, hotel, dinner := c.Events[0], c.Events[1], c.Events[2], c.Events[3]
+	if trip.UID != "trip-1@tripit.com" || !trip.AllDay || trip.Start.Format("2006-01-02") != "2011-10-28" || trip.End.Format("2006-01-02") != "2011-11-03" {
+		t.Errorf("Unexpected trip event: %+v", trip)
+	}
+	if flight.UID != "air-10-11@tripit.com" || flight.Summary != "Flight XA100 JFK to LHR" || flight.Location != "New York, NY (JFK)" {
+		t.Errorf("Unexpected flight event: %+v", flight)
+	}
+	if flight.Start.Location().String() != "America/New_York" || flight.End.Location().String() != "Europe/London" {
+		t.Errorf("Unexpected flight zones: %v, %v", flight.Start, flight.End)
+	}
+	for _, s := range []string{"Confirmation: ABC123", "Record locator: XYZ789", "Seats: 12A"} {
+		if !strings.Contains(flight.Description, s) {
+			t.Errorf("Expected %q in flight description %q", s, flight.Description)
+		}
+	}
+	if hotel.UID != "lodging-20@tripit.com" || hotel.Summary != "The Hotel; London" || !strings.HasPrefix(hotel.Location, "The Hotel; London, 1 Long Street") ||
+		hotel.Latitude != 51.5 || hotel.Longitude != -0.12 || !strings.Contains(hotel.Description, "Supplier confirmation: H-55") {
+		t.Errorf("Unexpected hotel event: %+v", hotel)
+	}
+	if !strings.HasPrefix(dinner.UID, "restaurant-") || dinner.UID != testCalendar(t).Events[3].UID {
+		t.Errorf("Expected a stable UID for an object without ID, got %q", dinner.UID)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	c := testCalendar(t)
+	var buf bytes.Buffer
+	n, err := c.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("Expected %d bytes written, got %d", buf.Len(), n)
+	}
+	validate(t, buf.String())
+
+	// Round trip the events.
+	parsed, err := Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.ProdID != DefaultProdID || parsed.Name != c.Name {
+		t.Errorf("Unexpected calendar %q, %q", parsed.ProdID, parsed.Name)
+	}
+	events := parsed.Events
+	if len(events) != len(c.Events) {
+		t.Fatalf("Expected %d events, got %d", len(c.Events), len(events))
+	}
+	for i, e := range c.Events {
+		p := events[i]
+		if p.UID != e.UID || p.Summary != e.Summary || p.Location != e.Location || p.Description != e.Description || p.URL != e.URL {
+			t.Errorf("Event %d: expected %+v, got %+v", i, e, p)
+		}
+		end := e.End
+		if !end.After(e.Start) {
+			end = time.Time{}
+		}
+		if p.AllDay != e.AllDay || !p.Start.Equal(e.Start) || !p.End.Equal(end) {
+			t.Errorf("Event %d: expected %v - %v, got %v - %v", i, e.Start, end, p.Start, p.End)
+		}
+		if p.Latitude != e.Latitude || p.Longitude != e.Longitude {
+			t.Errorf("Event %d: expected GEO %v;%v, got %v;%v", i, e.Latitude, e.Longitude, p.Latitude, p.Longitude)
+		}
+	}
+
+	// The output is stable.
+	var again bytes.Buffer
+	if _, err := testCalendar(t).WriteTo(&again); err != nil {
+		t.Fatal(err)
+	}
+	if again.String() != buf.String() {
+		t.Error("Expected the same output for the same itinerary")
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	var buf bytes.Buffer
+	lw := &lineWriter{w: bufio.NewWriter(&buf)}
+	d := time.Date(2011, 6, 1, 0, 0, 0, 0, loc)
+	writeTimezone(lw, loc, d, d)
+	lw.w.Flush()
+	expected := strings.Join([]string{
+		"BEGIN:VTIMEZONE", "TZID:America/New_York",
+		"BEGIN:STANDARD", "DTSTART:20110101T000000", "TZOFFSETFROM:-0500", "TZOFFSETTO:-0500", "TZNAME:EST", "END:STANDARD",
+		"BEGIN:DAYLIGHT", "DTSTART:20110313T020000", "TZOFFSETFROM:-0500", "TZOFFSETTO:-0400", "TZNAME:EDT", "END:DAYLIGHT",
+		"BEGIN:STANDARD", "DTSTART:20111106T020000", "TZOFFSETFROM:-0400", "TZOFFSETTO:-0500", "TZNAME:EST", "END:STANDARD",
+		"END:VTIMEZONE", "",
+	}, "\r\n")
+	if buf.String() != expected {
+		t.Errorf("Unexpected VTIMEZONE:\n%s", buf.String())
+	}
+	if s := formatOffset(5*3600 + 30*60); s != "+0530" {
+		t.Errorf("Expected +0530, got %s", s)
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	var buf bytes.Buffer
+	lw := &lineWriter{w: bufio.NewWriter(&buf)}
+	long := "DESCRIPTION:" + strings.Repeat("é", 100)
+	lw.line(long)
+	lw.w.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("Expected folded lines, got %q", lines)
+	}
+	for _, l := range lines {
+		if len(l) > 75 || !utf8.ValidString(l) {
+			t.Errorf("Invalid folded line %q", l)
+		}
+	}
+	if unfold(lines)[0] != long {
+		t.Error("Unfolded line does not match")
+	}
+}
+
+// validate checks the iCalendar object for the RFC 5545 rules the package relies on.
+func validate(t *testing.T, s string) {
+	t.Helper()
+	if !strings.HasSuffix(s, "\r\n") {
+		t.Fatal("Expected the object to end with CRLF")
+	}
+	raw := strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n")
+	for i, l := range raw {
+		if strings.ContainsAny(l, "\r\n") {
+			t.Errorf("Line %d: bare CR or LF in %q", i+1, l)
+		}
+		if len(l) > 75 {
+			t.Errorf("Line %d: %d octets is longer than 75", i+1, len(l))
+		}
+		if !utf8.ValidString(l) {
+			t.Errorf("Line %d: invalid UTF-8", i+1)
+		}
+	}
+	lines := unfold(raw)
+
+	type component struct {
+		name       string
+		props      map[string]bool
+		start, end time.Time
+	}
+	var stack []*component
+	zones := map[string]bool{}
+	var tzids []string
+	for i, l := range lines {
+		name, params, value, err := parseLine(l)
+		if err != nil {
+			t.Fatalf("Line %d: %v", i+1, err)
+		}
+		if name == "BEGIN" {
+			stack = append(stack, &component{name: value, props: map[string]bool{}})
+			continue
+		}
+		if len(stack) == 0 {
+			t.Fatalf("Line %d: content outside of a component", i+1)
+		}
+		c := stack[len(stack)-1]
+		if name == "END" {
+			if c.name != value {
+				t.Fatalf("Line %d: unexpected END:%s in %s", i+1, value, c.name)
+			}
+			var required []string
+			switch value {
+			case "VCALENDAR":
+				required = []string{"VERSION", "PRODID"}
+			case "VTIMEZONE":
+				required = []string{"TZID"}
+			case "STANDARD", "DAYLIGHT":
+				required = []string{"DTSTART", "TZOFFSETFROM", "TZOFFSETTO"}
+			case "VEVENT":
+				required = []string{"UID", "DTSTAMP", "DTSTART"}
+				if c.props["DTEND"] && !c.end.After(c.start) {
+					t.Errorf("Line %d: DTEND %v is not after DTSTART %v", i+1, c.end, c.start)
+				}
+			}
+			for _, p := range required {
+				if !c.props[p] {
+					t.Errorf("Line %d: %s is missing %s", i+1, value, p)
+				}
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if c.props[name] && name != "TZNAME" {
+			t.Errorf("Line %d: duplicate %s in %s", i+1, name, c.name)
+		}
+		c.props[name] = true
+		if name == "TZID" && c.name == "VTIMEZONE" {
+			zones[value] = true
+		}
+		if id, ok := params["TZID"]; ok {
+			tzids = append(tzids, id)
+		}
+		if c.name == "VEVENT" && (name == "DTSTART" || name == "DTEND") {
+			tm, _, err := parseTime(params, value, nil)
+			if err != nil {
+				t.Errorf("Line %d: %v", i+1, err)
+			}
+			if name == "DTSTART" {
+				c.start = tm
+			} else {
+				c.end = tm
+			}
+		}
+	}
+	if len(stack) != 0 {
+		t.Errorf("Unclosed components %v", stack)
+	}
+	for _, id := range tzids {
+		if !zones[id] {
+			t.Errorf("TZID %s has no VTIMEZONE", id)
+		}
+	}
+}
+
+// unfold joins folded lines.
+func unfold(raw []string) []string {
+	var lines []string
+	for _, l := range raw {
+		if strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t") {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
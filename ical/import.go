@@ -0,0 +1,280 @@
+package ical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ancientlore/go-tripit"
+)
+
+// Rule maps events that mention one of its keywords to a TripIt object type.
+type Rule struct {
+	ObjectType string   // tripit.ObjectTypeActivity or tripit.ObjectTypeRestaurant
+	DetailType string   // optional, detail type code for activities, for example tripit.ActivityDetailTypeMeeting
+	Keywords   []string // words or phrases, matched as whole words ignoring case
+}
+
+// DefaultRules recognize meals, concerts, theatre, tours and conferences.
+var DefaultRules = []Rule{
+	{ObjectType: tripit.ObjectTypeRestaurant, Keywords: []string{"breakfast", "brunch", "lunch", "dinner", "supper", "restaurant", "bistro", "brasserie", "trattoria", "steakhouse", "table for"}},
+	{ObjectType: tripit.ObjectTypeActivity, DetailType: tripit.ActivityDetailTypeConcert, Keywords: []string{"concert", "gig", "recital", "symphony", "orchestra"}},
+	{ObjectType: tripit.ObjectTypeActivity, DetailType: tripit.ActivityDetailTypeTheatre, Keywords: []string{"theatre", "theater", "musical", "opera", "ballet"}},
+	{ObjectType: tripit.ObjectTypeActivity, DetailType: tripit.ActivityDetailTypeTour, Keywords: []string{"tour", "excursion", "sightseeing", "guided walk"}},
+	{ObjectType: tripit.ObjectTypeActivity, DetailType: tripit.ActivityDetailTypeMeeting, Keywords: []string{"conference", "summit", "symposium", "meeting", "workshop", "meetup", "keynote", "seminar", "expo"}},
+}
+
+// Importer creates TripIt activities and restaurant reservations from calendar events.
+// The summary of an event is matched against the rules first, then its location and
+// then its description, so that "Lunch" in the description of a conference does not
+// make it a restaurant reservation. Within each field, the first matching rule is used.
+type Importer struct {
+	TripId  string    // optional, trip the objects are added to; if empty, TripIt chooses the trip
+	Rules   []Rule    // rules to match; nil uses DefaultRules
+	Default *Rule     // optional, rule for events that match no rule; if nil, those events are skipped
+	DryRun  bool      // Create prints the objects it would create instead of creating them
+	Output  io.Writer // output for DryRun; nil uses os.Stdout
+}
+
+// Match returns the rule for an event, or false if the event should be skipped.
+func (im *Importer) Match(e *Event) (Rule, bool) {
+	rules := im.Rules
+	if rules == nil {
+		rules = DefaultRules
+	}
+	for _, field := range []string{e.Summary, e.Location, e.Description} {
+		field = strings.ToLower(field)
+		for _, r := range rules {
+			for _, k := range r.Keywords {
+				if containsWord(field, strings.ToLower(k)) {
+					return r, true
+				}
+			}
+		}
+	}
+	if im.Default != nil {
+		return *im.Default, true
+	}
+	return Rule{}, false
+}
+
+// Request returns the request that creates the object for an event, or nil if the event
+// is skipped. Activities that end on a later day than they start get an EndDateTime;
+// otherwise the end is given by EndTime.
+func (im *Importer) Request(e *Event) *tripit.Request {
+	rule, ok := im.Match(e)
+	if !ok {
+		return nil
+	}
+	name, addr := splitLocation(e.Location)
+	start := dateTimeOf(e.Start, e.AllDay)
+	switch rule.ObjectType {
+	case tripit.ObjectTypeActivity:
+		o := &tripit.ActivityObject{
+			TripId:         im.TripId,
+			DisplayName:    e.Summary,
+			DetailTypeCode: rule.DetailType,
+			StartDateTime:  start,
+			LocationName:   name,
+			Address:        addr,
+			SupplierUrl:    e.URL,
+			Notes:          e.Description,
+		}
+		end := e.End.In(e.Start.Location())
+		switch {
+		case e.AllDay && end.After(e.Start.AddDate(0, 0, 1)):
+			// The end of an all-day event is the day after its last day.
+			o.EndDateTime = dateTimeOf(end.AddDate(0, 0, -1), true)
+		case e.AllDay || !end.After(e.Start):
+		case end.Format("2006-01-02") != e.Start.Format("2006-01-02"):
+			o.EndDateTime = dateTimeOf(end, false)
+		default:
+			o.EndTime = end.Format("15:04:05")
+		}
+		return &tripit.Request{ActivityObject: o}
+	case tripit.ObjectTypeRestaurant:
+		return &tripit.Request{RestaurantObject: &tripit.RestaurantObject{
+			TripId:       im.TripId,
+			DisplayName:  e.Summary,
+			DateTime:     start,
+			SupplierName: name,
+			Address:      addr,
+			SupplierUrl:  e.URL,
+			Notes:        e.Description,
+		}}
+	}
+	return nil
+}
+
+// Requests returns the requests for the events in the calendar that are not skipped.
+func (im *Importer) Requests(c *Calendar) []*tripit.Request {
+	var result []*tripit.Request
+	for _, e := range c.Events {
+		if r := im.Request(e); r != nil {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Create creates the objects for the events in the calendar. It returns the responses
+// for the objects created before any error. If DryRun is set, Create prints a line for
+// each object instead, and returns no responses.
+func (im *Importer) Create(ctx context.Context, client *tripit.TripIt, c *Calendar) ([]*tripit.Response, error) {
+	var result []*tripit.Response
+	for _, r := range im.Requests(c) {
+		if im.DryRun {
+			w := im.Output
+			if w == nil {
+				w = os.Stdout
+			}
+			if _, err := fmt.Fprintln(w, Describe(r)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		resp, err := client.CreateContext(ctx, r)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, resp)
+	}
+	return result, nil
+}
+
+// Describe returns a one-line description of an activity or restaurant request, such as
+//
+//	activity "GopherCon" (meeting) on 2024-06-18 09:00 America/Chicago until 17:00 at McCormick Place
+//
+// Activities that end on a later day are described with the end date, as in "until
+// 2024-06-20 17:00".
+func Describe(r *tripit.Request) string {
+	var kind, detail, name, place, until string
+	var dt *tripit.DateTime
+	switch {
+	case r.ActivityObject != nil:
+		o := r.ActivityObject
+		kind, detail, name, dt = tripit.ObjectTypeActivity, detailTypes[o.DetailTypeCode], o.DisplayName, o.StartDateTime
+		place = join(", ", o.LocationName, addressOf(o.Address))
+		until = trimSeconds(o.EndTime)
+		if o.EndDateTime != nil {
+			until = join(" ", o.EndDateTime.Date, trimSeconds(o.EndDateTime.Time))
+		}
+	case r.RestaurantObject != nil:
+		o := r.RestaurantObject
+		kind, name, dt = tripit.ObjectTypeRestaurant, o.DisplayName, o.DateTime
+		place = join(", ", o.SupplierName, addressOf(o.Address))
+	default:
+		return "unsupported request"
+	}
+	s := kind + " " + strconv.Quote(name)
+	if detail != "" {
+		s += " (" + detail + ")"
+	}
+	if dt != nil {
+		s += " on " + join(" ", dt.Date, trimSeconds(dt.Time), dt.Timezone)
+	}
+	if until != "" {
+		s += " until " + until
+	}
+	if place != "" {
+		s += " at " + place
+	}
+	return s
+}
+
+// detailTypes names the activity detail types.
+var detailTypes = map[string]string{
+	tripit.ActivityDetailTypeConcert: "concert",
+	tripit.ActivityDetailTypeTheatre: "theatre",
+	tripit.ActivityDetailTypeMeeting: "meeting",
+	tripit.ActivityDetailTypeTour:    "tour",
+}
+
+// dateTimeOf converts an event time. All-day events have a date only, and floating
+// times have no time zone, so that TripIt uses the local time of the place.
+func dateTimeOf(t time.Time, allDay bool) *tripit.DateTime {
+	dt := new(tripit.DateTime)
+	switch {
+	case allDay:
+		dt.Date = t.Format("2006-01-02")
+	case t.Location() == time.Local:
+		dt.Date, dt.Time = t.Format("2006-01-02"), t.Format("15:04:05")
+	default:
+		dt.SetTime(t)
+	}
+	return dt
+}
+
+// splitLocation splits a LOCATION value into a place name and an address. A location
+// that starts with a street number is an address. Otherwise a location with commas is
+// taken to be a name followed by an address, such as "Hyatt Regency, 151 E Wacker Dr,
+// Chicago", and a location without commas is used as both the name and the address.
+func splitLocation(loc string) (string, *tripit.Address) {
+	loc = strings.TrimSpace(loc)
+	name, rest, ok := strings.Cut(loc, ",")
+	name, rest = strings.TrimSpace(name), strings.TrimSpace(rest)
+	switch {
+	case loc == "":
+		return "", nil
+	case startsWithDigit(name):
+		return "", &tripit.Address{Address: loc}
+	case !ok || rest == "":
+		return name, &tripit.Address{Address: name}
+	}
+	return name, &tripit.Address{Address: rest}
+}
+
+// addressOf returns the one-line address, or an empty string if a is nil.
+func addressOf(a *tripit.Address) string {
+	if a == nil {
+		return ""
+	}
+	return a.Address
+}
+
+// startsWithDigit returns true if s starts with a digit, as street addresses often do.
+func startsWithDigit(s string) bool {
+	return s != "" && unicode.IsDigit(rune(s[0]))
+}
+
+// trimSeconds removes the seconds from an xs:time value.
+func trimSeconds(s string) string {
+	if len(s) == 8 && strings.HasSuffix(s, ":00") {
+		return s[:5]
+	}
+	return s
+}
+
+// containsWord returns true if s contains word, not preceded or followed by a letter or digit.
+func containsWord(s, word string) bool {
+	if word == "" {
+		return false
+	}
+	for i := 0; ; {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if !isWordByte(s, start-1) && !isWordByte(s, end) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// isWordByte returns true if s[i] is part of a word. Bytes of multi-byte characters are
+// treated as letters.
+func isWordByte(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	c := s[i]
+	return c >= 0x80 || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -0,0 +1,258 @@
+package ical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ancientlore/go-tripit"
+)
+
+// invites is an iCalendar object as sent by Outlook and other clients, with a Windows
+// time zone name, folded lines, an alarm and a floating time.
+var invites = strings.ReplaceAll(`BEGIN:VCALENDAR
+PRODID:-//Microsoft Corporation//Outlook 16.0 MIMEDIR//EN
+VERSION:2.0
+BEGIN:VEVENT
+UID:conf-1
+DTSTART;TZID="Central Standard Time":20240618T090000
+DTEND;TZID="Central Standard Time":20240618T170000
+SUMMARY:GopherCon 2024 Conference
+LOCATION:McCormick Place\, 2301 S King Dr\, Chicago
+DESCRIPTION:Lunch is provided.\nBring your badge.
+URL:https://www.gophercon.com/
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:Reminder
+TRIGGER:-PT15M
+END:VALARM
+END:VEVENT
+BEGIN:VTIMEZONE
+TZID:Central Standard Time
+BEGIN:STANDARD
+DTSTART:16011104T020000
+TZOFFSETFROM:-0500
+TZOFFSETTO:-0600
+END:STANDARD
+BEGIN:DAYLIGHT
+DTSTART:16010311T020000
+TZOFFSETFROM:-0600
+TZOFFSETTO:-0500
+END:DAYLIGHT
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:dinner-1
+DTSTART;TZID=America/Chicago:20240618T193000
+DURATION:PT2H
+SUMMARY:Team dinner
+LOCATION:Girl & the Goat\, 809 W Randolph St\, Chicago\, IL
+DESCRIPTION:Reservation for 8 people under the name Smith. Please arrive on
+  time.
+END:VEVENT
+BEGIN:VEVENT
+UID:walk-1
+DTSTART:20240619T080000
+DTEND:20240619T100000
+SUMMARY:Architecture boat tour
+LOCATION:401 N Michigan Ave
+END:VEVENT
+BEGIN:VEVENT
+UID:day-1
+DTSTART;VALUE=DATE:20240620
+SUMMARY:Free day
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n")
+
+func TestParse(t *testing.T) {
+	c, err := Parse(strings.NewReader(invites))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ProdID != "-//Microsoft Corporation//Outlook 16.0 MIMEDIR//EN" || len(c.Events) != 4 {
+		t.Fatalf("Unexpected calendar %q with %d events", c.ProdID, len(c.Events))
+	}
+	conf, dinner, tour, day := c.Events[0], c.Events[1], c.Events[2], c.Events[3]
+	if conf.Location != "McCormick Place, 2301 S King Dr, Chicago" || conf.Description != "Lunch is provided.\nBring your badge." || conf.URL != "https://www.gophercon.com/" {
+		t.Errorf("Unexpected event: %+v", conf)
+	}
+	if _, offset := conf.Start.Zone(); offset != -6*3600 || conf.Start.Hour() != 9 || conf.End.Sub(conf.Start) != 8*time.Hour {
+		t.Errorf("Expected the standard offset of the VTIMEZONE, got %v - %v", conf.Start, conf.End)
+	}
+	if dinner.Start.Location().String() != "America/Chicago" || dinner.End.Sub(dinner.Start) != 2*time.Hour ||
+		!strings.HasSuffix(dinner.Description, "arrive on time.") {
+		t.Errorf("Unexpected event: %+v", dinner)
+	}
+	if tour.Start.Location() != time.Local || tour.Start.Hour() != 8 {
+		t.Errorf("Expected a floating time, got %v", tour.Start)
+	}
+	if !day.AllDay || day.Start.Format("2006-01-02") != "2024-06-20" || day.End.Format("2006-01-02") != "2024-06-21" {
+		t.Errorf("Unexpected all-day event: %+v", day)
+	}
+
+	for _, s := range []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:x\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Nowhere:20240101T000000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nno colon\r\nEND:VCALENDAR\r\n",
+	} {
+		if _, err := Parse(strings.NewReader(s)); err == nil || !strings.HasPrefix(err.Error(), "ical: ") {
+			t.Errorf("Expected an error for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for s, d := range map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"-PT15M":  -15 * time.Minute,
+		"P1DT12H": 36 * time.Hour,
+	} {
+		if v, err := parseDuration(s); err != nil || v != d {
+			t.Errorf("%s: expected %v, got %v, %v", s, d, v, err)
+		}
+	}
+	for _, s := range []string{"", "P", "PT", "1H", "PTH", "PT1"} {
+		if _, err := parseDuration(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestImporter(t *testing.T) {
+	c, err := Parse(strings.NewReader(invites))
+	if err != nil {
+		t.Fatal(err)
+	}
+	im := &Importer{TripId: "7"}
+	reqs := im.Requests(c)
+	if len(reqs) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(reqs))
+	}
+
+	// "Lunch" in the description does not override the summary.
+	a := reqs[0].ActivityObject
+	if a == nil || a.TripId != "7" || a.DisplayName != "GopherCon 2024 Conference" || a.DetailTypeCode != tripit.ActivityDetailTypeMeeting ||
+		a.LocationName != "McCormick Place" || a.Address.Address != "2301 S King Dr, Chicago" || a.EndTime != "17:00:00" ||
+		a.SupplierUrl != "https://www.gophercon.com/" {
+		t.Errorf("Unexpected activity: %+v", reqs[0])
+	} else if dt := a.StartDateTime; dt.Date != "2024-06-18" || dt.Time != "09:00:00" || dt.UtcOffset != "-06:00" || dt.Timezone != "" {
+		t.Errorf("Unexpected start: %+v", dt)
+	}
+
+	r := reqs[1].RestaurantObject
+	if r == nil || r.SupplierName != "Girl & the Goat" || r.DateTime.Timezone != "America/Chicago" || r.DateTime.Time != "19:30:00" {
+		t.Errorf("Unexpected restaurant: %+v", reqs[1])
+	}
+
+	a = reqs[2].ActivityObject
+	if a == nil || a.DetailTypeCode != tripit.ActivityDetailTypeTour || a.LocationName != "" || a.Address.Address != "401 N Michigan Ave" {
+		t.Errorf("Unexpected tour: %+v", reqs[2])
+	} else if dt := a.StartDateTime; dt.Date != "2024-06-19" || dt.Time != "08:00:00" || dt.UtcOffset != "" || dt.Timezone != "" {
+		t.Errorf("Expected a floating start, got %+v", dt)
+	}
+
+	// Events that match no rule use the default rule.
+	im.Default = &Rule{ObjectType: tripit.ObjectTypeActivity}
+	if reqs := im.Requests(c); len(reqs) != 4 || reqs[3].ActivityObject.StartDateTime.Date != "2024-06-20" || reqs[3].ActivityObject.StartDateTime.Time != "" {
+		t.Errorf("Unexpected requests with a default rule: %+v", reqs)
+	}
+
+	// Custom rules replace the default rules, and keywords match whole words.
+	im = &Importer{Rules: []Rule{{ObjectType: tripit.ObjectTypeRestaurant, Keywords: []string{"Boat"}}}}
+	if reqs := im.Requests(c); len(reqs) != 1 || reqs[0].RestaurantObject == nil {
+		t.Errorf("Unexpected requests with custom rules: %+v", reqs)
+	}
+	if containsWord("detour", "tour") || !containsWord("city tour.", "tour") || !containsWord("table for 2", "table for") {
+		t.Error("Unexpected keyword match")
+	}
+}
+
+func TestImporterMultiDay(t *testing.T) {
+	c, err := Parse(strings.NewReader(strings.ReplaceAll(`BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:expo-1
+DTSTART;VALUE=DATE:20240620
+DTEND;VALUE=DATE:20240623
+SUMMARY:Travel Expo
+END:VEVENT
+BEGIN:VEVENT
+UID:summit-1
+DTSTART;TZID=America/Chicago:20240624T090000
+DTEND;TZID=America/Chicago:20240625T170000
+SUMMARY:Gopher Summit
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := (&Importer{}).Requests(c)
+	if len(reqs) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(reqs))
+	}
+
+	// DTEND of an all-day event is exclusive, so the expo ends on June 22.
+	a := reqs[0].ActivityObject
+	if a.StartDateTime.Date != "2024-06-20" || a.EndDateTime == nil || a.EndDateTime.Date != "2024-06-22" || a.EndDateTime.Time != "" || a.EndTime != "" {
+		t.Errorf("Unexpected all-day activity: %+v %+v", a.StartDateTime, a.EndDateTime)
+	}
+	a = reqs[1].ActivityObject
+	if a.EndDateTime == nil || a.EndDateTime.Date != "2024-06-25" || a.EndDateTime.Time != "17:00:00" || a.EndDateTime.Timezone != "America/Chicago" || a.EndTime != "" {
+		t.Errorf("Unexpected activity: %+v %+v", a.StartDateTime, a.EndDateTime)
+	}
+	if s := Describe(reqs[0]); s != `activity "Travel Expo" (meeting) on 2024-06-20 until 2024-06-22` {
+		t.Errorf("Unexpected description: %s", s)
+	}
+}
+
+func TestImporterCreate(t *testing.T) {
+	c, err := Parse(strings.NewReader(invites))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	im := &Importer{DryRun: true, Output: &buf}
+	resps, err := im.Create(context.Background(), nil, c)
+	if err != nil || resps != nil {
+		t.Fatalf("Unexpected dry run result %v, %v", resps, err)
+	}
+	expected := `activity "GopherCon 2024 Conference" (meeting) on 2024-06-18 09:00 until 17:00 at McCormick Place, 2301 S King Dr, Chicago
+restaurant "Team dinner" on 2024-06-18 19:30 America/Chicago at Girl & the Goat, 809 W Randolph St, Chicago, IL
+activity "Architecture boat tour" (tour) on 2024-06-19 08:00 until 10:00 at 401 N Michigan Ave
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected dry run output:\n%s", buf.String())
+	}
+
+	var created []tripit.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/create/format/json" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var req tripit.Request
+		if err := json.Unmarshal([]byte(r.PostFormValue("json")), &req); err != nil {
+			t.Error(err)
+		}
+		created = append(created, req)
+		w.Write([]byte(`{"ActivityObject":{"id":"1"}}`))
+	}))
+	defer srv.Close()
+	client := tripit.NewClient(tripit.NewOAuth3LeggedCredential("key", "secret", "token", "tokensecret"), tripit.WithApiUrl(srv.URL), tripit.WithHttpClient(srv.Client()))
+	im.DryRun = false
+	resps, err = im.Create(context.Background(), client, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resps) != 3 || len(created) != 3 || created[1].RestaurantObject == nil || created[1].RestaurantObject.DisplayName != "Team dinner" {
+		t.Errorf("Unexpected objects created: %+v", created)
+	}
+}
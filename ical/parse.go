@@ -0,0 +1,320 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse reads an iCalendar object and returns its events. Times with a TZID are in the
+// IANA time zone of that name if it can be loaded; otherwise the UTC offset of the
+// standard time observance in the object's VTIMEZONE component is used, as clients such
+// as Outlook use Windows zone names. Floating times are in time.Local. Recurrence rules
+// are ignored, so only the first occurrence of a recurring event is returned.
+func Parse(r io.Reader) (*Calendar, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &Calendar{}
+	offsets := make(map[string]int) // standard offsets of VTIMEZONE components by TZID
+	var stack []string
+	var e *Event
+	var tz string
+	type property struct {
+		n           int
+		name, value string
+		params      map[string]string
+	}
+	var times []property     // DTSTART, DTEND and DURATION of the current event
+	var pending [][]property // times of each event
+	for n, l := range lines {
+		n++
+		name, params, value, err := parseLine(l)
+		if err != nil {
+			return nil, fmt.Errorf("ical: line %d: %w", n, err)
+		}
+		component := ""
+		if len(stack) > 0 {
+			component = stack[len(stack)-1]
+		}
+		switch {
+		case name == "BEGIN":
+			stack = append(stack, strings.ToUpper(value))
+			switch stack[len(stack)-1] {
+			case "VEVENT":
+				e, times = new(Event), nil
+			case "VTIMEZONE":
+				tz = ""
+			}
+		case name == "END":
+			if component != strings.ToUpper(value) {
+				return nil, fmt.Errorf("ical: line %d: unexpected END:%s", n, value)
+			}
+			stack = stack[:len(stack)-1]
+			if component == "VEVENT" {
+				c.Events = append(c.Events, e)
+				pending = append(pending, times)
+				e = nil
+			}
+		case component == "VCALENDAR":
+			switch name {
+			case "PRODID":
+				c.ProdID = value
+			case "X-WR-CALNAME":
+				c.Name = unescape(value)
+			}
+		case component == "VTIMEZONE" && name == "TZID":
+			tz = value
+		case (component == "STANDARD" || component == "DAYLIGHT") && name == "TZOFFSETTO":
+			if _, ok := offsets[tz]; ok && component == "DAYLIGHT" {
+				break
+			}
+			offset, err := parseOffset(value)
+			if err != nil {
+				return nil, fmt.Errorf("ical: line %d: %w", n, err)
+			}
+			offsets[tz] = offset
+		case component == "VEVENT":
+			switch name {
+			case "UID":
+				e.UID = value
+			case "SUMMARY":
+				e.Summary = unescape(value)
+			case "DESCRIPTION":
+				e.Description = unescape(value)
+			case "LOCATION":
+				e.Location = unescape(value)
+			case "URL":
+				e.URL = value
+			case "GEO":
+				lat, lon, _ := strings.Cut(value, ";")
+				e.Latitude, _ = strconv.ParseFloat(lat, 64)
+				e.Longitude, _ = strconv.ParseFloat(lon, 64)
+			case "DTSTART", "DTEND", "DURATION":
+				// Parsed at the end, as VTIMEZONE components may follow the events.
+				times = append(times, property{n, name, value, params})
+			}
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("ical: missing END:%s", stack[len(stack)-1])
+	}
+	for i, e := range c.Events {
+		var duration string
+		for _, p := range pending[i] {
+			if p.name == "DURATION" {
+				duration = p.value
+				continue
+			}
+			t, allDay, err := parseTime(p.params, p.value, offsets)
+			if err != nil {
+				return nil, fmt.Errorf("ical: line %d: %s: %w", p.n, p.name, err)
+			}
+			if p.name == "DTSTART" {
+				e.Start, e.AllDay = t, allDay
+			} else {
+				e.End = t
+			}
+		}
+		if e.Start.IsZero() {
+			return nil, fmt.Errorf("ical: event %q has no DTSTART", e.UID)
+		}
+		if duration != "" && e.End.IsZero() {
+			d, err := parseDuration(duration)
+			if err != nil {
+				return nil, fmt.Errorf("ical: event %q: %w", e.UID, err)
+			}
+			e.End = e.Start.Add(d)
+		}
+		if e.AllDay && e.End.IsZero() {
+			e.End = e.Start.AddDate(0, 0, 1)
+		}
+	}
+	return c, nil
+}
+
+// readLines reads and unfolds the content lines. Lines may end with CRLF or LF.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		l := strings.TrimSuffix(s.Text(), "\r")
+		switch {
+		case l == "":
+		case (l[0] == ' ' || l[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1] += l[1:]
+		default:
+			lines = append(lines, l)
+		}
+	}
+	return lines, s.Err()
+}
+
+// parseLine splits a content line into its upper case name, its parameters and its
+// value. Parameter names are upper case and quotes are removed from parameter values.
+func parseLine(l string) (name string, params map[string]string, value string, err error) {
+	quoted := false
+	end := -1
+	for i := 0; i < len(l) && end < 0; i++ {
+		switch l[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				end = i
+			}
+		}
+	}
+	if end <= 0 {
+		return "", nil, "", fmt.Errorf("invalid content line %q", l)
+	}
+	parts := splitParams(l[:end])
+	params = make(map[string]string)
+	for _, p := range parts[1:] {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			return "", nil, "", fmt.Errorf("invalid parameter %q", p)
+		}
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, l[end+1:], nil
+}
+
+// splitParams splits the name and parameters of a content line at semicolons that are
+// not quoted.
+func splitParams(s string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseTime parses a DATE or DATE-TIME value. It returns true for a DATE.
+func parseTime(params map[string]string, value string, offsets map[string]int) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, time.UTC)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	loc := time.Local
+	if id, ok := params["TZID"]; ok {
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimPrefix(id, "/")); err != nil {
+			offset, ok := offsets[id]
+			if !ok {
+				return time.Time{}, false, fmt.Errorf("unknown time zone %q", id)
+			}
+			loc = time.FixedZone(id, offset)
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseOffset parses a UTC-OFFSET value, such as "-0500", and returns seconds east of UTC.
+func parseOffset(s string) (int, error) {
+	if len(s) != 5 && len(s) != 7 || s[0] != '+' && s[0] != '-' {
+		return 0, fmt.Errorf("invalid UTC offset %q", s)
+	}
+	offset := 0
+	for i, mul := range []int{3600, 60, 1} {
+		if 1+2*i >= len(s) {
+			break
+		}
+		v, err := strconv.Atoi(s[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid UTC offset %q", s)
+		}
+		offset += v * mul
+	}
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// parseDuration parses a DURATION value, such as "PT1H30M" or "P1D". Days and weeks are
+// treated as 24 hours.
+func parseDuration(s string) (time.Duration, error) {
+	v := strings.TrimPrefix(s, "+")
+	neg := strings.HasPrefix(v, "-")
+	v = strings.TrimPrefix(v, "-")
+	if !strings.HasPrefix(v, "P") || len(v) < 3 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var d time.Duration
+	n := 0
+	digits := false
+	for _, c := range v[1:] {
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+			digits = true
+			continue
+		}
+		var unit time.Duration
+		switch c {
+		case 'T':
+			continue
+		case 'W':
+			unit = 7 * 24 * time.Hour
+		case 'D':
+			unit = 24 * time.Hour
+		case 'H':
+			unit = time.Hour
+		case 'M':
+			unit = time.Minute
+		case 'S':
+			unit = time.Second
+		}
+		if unit == 0 || !digits {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += time.Duration(n) * unit
+		n, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// unescape reverses escape.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			if s[i] == 'n' || s[i] == 'N' {
+				b.WriteByte('\n')
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
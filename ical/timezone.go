@@ -0,0 +1,90 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// transition is a change of UTC offset in a time zone.
+type transition struct {
+	at         time.Time // instant of the change
+	fromOffset int       // offset before the change, in seconds east of UTC
+	toOffset   int       // offset after the change
+	name       string    // abbreviation after the change, for example "EDT"
+	dst        bool      // whether daylight saving time is in effect after the change
+}
+
+// transitions returns the offset changes in loc between from and to. Go does not expose
+// the rules of a time zone, so the changes are found by comparing offsets a day apart and
+// then searching for the second at which the offset changes.
+func transitions(loc *time.Location, from, to time.Time) []transition {
+	var result []transition
+	t := from.In(loc)
+	_, offset := t.Zone()
+	for t.Before(to) {
+		next := t.Add(24 * time.Hour)
+		if _, o := next.Zone(); o != offset {
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, mo := mid.Zone(); mo == offset {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			name, o := hi.Zone()
+			result = append(result, transition{at: hi, fromOffset: offset, toOffset: o, name: name, dst: hi.IsDST()})
+			offset = o
+		}
+		t = next
+	}
+	return result
+}
+
+// writeTimezone writes a VTIMEZONE component for loc, with the observances in effect
+// from the start of the year of from until the end of the year of to.
+func writeTimezone(lw *lineWriter, loc *time.Location, from, to time.Time) {
+	start := time.Date(from.In(loc).Year(), time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(to.In(loc).Year()+1, time.January, 1, 0, 0, 0, 0, loc)
+	name, offset := start.Zone()
+
+	lw.line("BEGIN:VTIMEZONE")
+	lw.line("TZID:" + loc.String())
+	writeObservance(lw, transition{at: start, fromOffset: offset, toOffset: offset, name: name, dst: start.IsDST()})
+	for _, tr := range transitions(loc, start, end) {
+		writeObservance(lw, tr)
+	}
+	lw.line("END:VTIMEZONE")
+}
+
+// writeObservance writes a STANDARD or DAYLIGHT component that starts at the transition.
+// DTSTART is the local time before the change, as required by RFC 5545.
+func writeObservance(lw *lineWriter, tr transition) {
+	kind := "STANDARD"
+	if tr.dst {
+		kind = "DAYLIGHT"
+	}
+	lw.line("BEGIN:" + kind)
+	lw.line("DTSTART:" + tr.at.In(time.FixedZone("", tr.fromOffset)).Format("20060102T150405"))
+	lw.line("TZOFFSETFROM:" + formatOffset(tr.fromOffset))
+	lw.line("TZOFFSETTO:" + formatOffset(tr.toOffset))
+	if tr.name != "" {
+		lw.line("TZNAME:" + escape(tr.name))
+	}
+	lw.line("END:" + kind)
+}
+
+// formatOffset formats an offset in seconds as a UTC-OFFSET value, for example "-0500".
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	s := fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
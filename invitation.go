@@ -0,0 +1,99 @@
+package tripit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// InviteToTrip invites the users with the given email addresses to the trip with the given
+// ID. The TripShare controls whether the invitees are travelers, whether they can edit the
+// trip and whether the invitation includes trip details; its TripId is set to tripId. The
+// message is optional.
+func (t *TripIt) InviteToTrip(ctx context.Context, tripId uint, emails []string, share TripShare, message string) (*Response, error) {
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("tripit: no email addresses to invite")
+	}
+	share.TripId = tripId
+	return t.postForm(ctx, "create", &Request{Invitation: []Invitation{{
+		EmailAddresses: emails,
+		TripShare:      &share,
+		Message:        message,
+	}}}, nil)
+}
+
+// RequestConnection asks the users with the given email addresses to connect with the user
+// on TripIt.
+func (t *TripIt) RequestConnection(ctx context.Context, emails []string) (*Response, error) {
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("tripit: no email addresses to connect with")
+	}
+	return t.postForm(ctx, "create", &Request{Invitation: []Invitation{{
+		EmailAddresses:    emails,
+		ConnectionRequest: &ConnectionRequest{},
+	}}}, nil)
+}
+
+// TripInvitee is a user invited to a trip, with the user's profile.
+type TripInvitee struct {
+	Invitee
+	InviteeProfile *Profile // the invitee's profile, or nil if TripIt did not return it
+}
+
+// GetInvitees gets the users invited to the trip with the given ID, together with their
+// profiles.
+func (t *TripIt) GetInvitees(ctx context.Context, tripId uint) ([]TripInvitee, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/id/%d", ObjectTypeTrip, tripId))
+	if err != nil {
+		return nil, err
+	}
+	trip := first(resp.Trip)
+	if trip == nil {
+		return nil, fmt.Errorf("%w: %s %d", ErrNotFound, ObjectTypeTrip, tripId)
+	}
+	invitees := trip.Invitees()
+	if len(invitees) == 0 {
+		return nil, nil
+	}
+	result := make([]TripInvitee, len(invitees))
+	for i := range invitees {
+		result[i] = TripInvitee{Invitee: invitees[i], InviteeProfile: invitees[i].Profile(resp)}
+	}
+	return result, nil
+}
+
+// emailAddresses is the JSON form of Invitation.EmailAddresses.
+type emailAddresses struct {
+	Address []string `json:"address,omitempty"`
+}
+
+// invitation is an Invitation without its JSON methods.
+type invitation Invitation
+
+// MarshalJSON encodes the invitation with its email addresses in an EmailAddresses object.
+func (v Invitation) MarshalJSON() ([]byte, error) {
+	w := struct {
+		EmailAddresses *emailAddresses `json:"EmailAddresses,omitempty"`
+		invitation
+	}{invitation: invitation(v)}
+	if len(v.EmailAddresses) > 0 {
+		w.EmailAddresses = &emailAddresses{v.EmailAddresses}
+	}
+	return json.Marshal(w)
+}
+
+// UnmarshalJSON decodes an invitation with its email addresses in an EmailAddresses object.
+func (v *Invitation) UnmarshalJSON(b []byte) error {
+	w := struct {
+		EmailAddresses *emailAddresses `json:"EmailAddresses,omitempty"`
+		*invitation
+	}{invitation: (*invitation)(v)}
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	v.EmailAddresses = nil
+	if w.EmailAddresses != nil {
+		v.EmailAddresses = w.EmailAddresses.Address
+	}
+	return nil
+}
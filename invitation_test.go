@@ -0,0 +1,90 @@
+package tripit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvitations(t *testing.T) {
+	var forms []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/create/format/json":
+			forms = append(forms, r.PostFormValue("json"))
+			w.Write([]byte(`{"timestamp":"1306543281"}`))
+		case "/v1/get/trip/id/1/format/json":
+			w.Write([]byte(`{"Trip":{"id":"1","TripInvitees":{"Invitee":[` +
+				`{"@attributes":{"profile_ref":"abc"},"is_read_only":"false","is_traveler":"true"},` +
+				`{"@attributes":{"profile_ref":"xyz"},"is_read_only":"true","is_traveler":"false"}]}},` +
+				`"Profile":[{"@attributes":{"ref":"me"},"screen_name":"owner"},{"@attributes":{"ref":"abc"},"screen_name":"friend"}]}`))
+		default:
+			w.Write([]byte(`{"timestamp":"1306543281"}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+	ctx := context.Background()
+
+	if _, err := c.InviteToTrip(ctx, 1, []string{"a@site.com", "b@site.com"}, TripShare{IsTraveler: true}, "Join us"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RequestConnection(ctx, []string{"c@site.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RequestConnection(ctx, nil); err == nil {
+		t.Error("Expected error for missing email addresses")
+	}
+	expected := []string{
+		`{"Invitation":[{"EmailAddresses":{"address":["a@site.com","b@site.com"]},"TripShare":{"trip_id":"1","is_traveler":"true"},"message":"Join us"}]}`,
+		`{"Invitation":[{"EmailAddresses":{"address":["c@site.com"]},"ConnectionRequest":{}}]}`,
+	}
+	if len(forms) != len(expected) {
+		t.Fatalf("Expected %d requests, got %d", len(expected), len(forms))
+	}
+	for i := range expected {
+		var a, b interface{}
+		json.Unmarshal([]byte(forms[i]), &a)
+		json.Unmarshal([]byte(expected[i]), &b)
+		if mustMarshal(a) != mustMarshal(b) {
+			t.Errorf("Expected %s, got %s", expected[i], forms[i])
+		}
+	}
+
+	invitees, err := c.GetInvitees(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invitees) != 2 {
+		t.Fatalf("Expected 2 invitees, got %d", len(invitees))
+	}
+	if !invitees[0].IsTraveler || invitees[0].InviteeProfile == nil || invitees[0].InviteeProfile.ScreenName != "friend" {
+		t.Errorf("Unexpected invitee: %+v", invitees[0])
+	}
+	if !invitees[1].IsReadOnly || invitees[1].InviteeProfile != nil {
+		t.Errorf("Unexpected invitee: %+v", invitees[1])
+	}
+}
+
+func mustMarshal(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestInvitationEncoding(t *testing.T) {
+	v := Invitation{EmailAddresses: []string{"a@site.com", "b@site.com"}, Message: "Hi"}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) != `{"EmailAddresses":{"address":["a@site.com","b@site.com"]},"message":"Hi"}` {
+		t.Errorf("Unexpected JSON: %s, %v", b, err)
+	}
+	var w Invitation
+	if err := json.Unmarshal(b, &w); err != nil || len(w.EmailAddresses) != 2 || w.EmailAddresses[1] != "b@site.com" || w.Message != "Hi" {
+		t.Errorf("Unexpected invitation: %+v, %v", w, err)
+	}
+	b, err = XmlCodec.Encode(v)
+	if err != nil || string(b) != `<Invitation><EmailAddresses><address>a@site.com</address><address>b@site.com</address></EmailAddresses><message>Hi</message></Invitation>` {
+		t.Errorf("Unexpected XML: %s, %v", b, err)
+	}
+}
@@ -0,0 +1,245 @@
+package tripit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ItineraryItem is a single entry in an itinerary: an object, or one segment of an
+// air, rail, transport or cruise object.
+type ItineraryItem struct {
+	Time    time.Time   // start time of the object or segment; zero if unknown
+	Type    string      // object type, one of the ObjectType constants
+	Object  interface{} // the object, for example *AirObject
+	Segment interface{} // the segment, for example *AirSegment; nil for objects without segments
+}
+
+// Itinerary groups a trip with all of its objects.
+type Itinerary struct {
+	Trip       *Trip
+	Air        []*AirObject
+	Lodging    []*LodgingObject
+	Car        []*CarObject
+	Rail       []*RailObject
+	Cruise     []*CruiseObject
+	Transport  []*TransportObject
+	Activity   []*ActivityObject
+	Restaurant []*RestaurantObject
+	Note       []*NoteObject
+	Map        []*MapObject
+	Directions []*DirectionsObject
+	Weather    []*WeatherObject
+	Items      []ItineraryItem // objects and segments in chronological order; items without a time are last
+}
+
+// NewItineraries groups the objects in the response under their trips, using the objects'
+// TripId fields. Objects that don't belong to a trip in the response are ignored. The
+// response is usually the result of a trip request with include_objects set.
+func NewItineraries(resp *Response) []*Itinerary {
+	var result []*Itinerary
+	byId := make(map[string]*Itinerary)
+	for _, trip := range resp.Trip {
+		if trip == nil {
+			continue
+		}
+		it := &Itinerary{Trip: trip}
+		result = append(result, it)
+		byId[trip.Id] = it
+	}
+	for _, o := range resp.AirObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Air = append(it.Air, o)
+			for _, s := range o.Segment {
+				it.add(ObjectTypeAir, o, s, s.StartDateTime)
+			}
+		}
+	}
+	for _, o := range resp.LodgingObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Lodging = append(it.Lodging, o)
+			it.add(ObjectTypeLodging, o, nil, o.StartDateTime)
+		}
+	}
+	for _, o := range resp.CarObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Car = append(it.Car, o)
+			it.add(ObjectTypeCar, o, nil, o.StartDateTime)
+		}
+	}
+	for _, o := range resp.RailObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Rail = append(it.Rail, o)
+			for _, s := range o.Segment {
+				it.add(ObjectTypeRail, o, s, s.StartDateTime)
+			}
+		}
+	}
+	for _, o := range resp.CruiseObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Cruise = append(it.Cruise, o)
+			for _, s := range o.Segment {
+				it.add(ObjectTypeCruise, o, s, s.StartDateTime)
+			}
+		}
+	}
+	for _, o := range resp.TransportObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Transport = append(it.Transport, o)
+			for _, s := range o.Segment {
+				it.add(ObjectTypeTransport, o, s, s.StartDateTime)
+			}
+		}
+	}
+	for _, o := range resp.ActivityObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Activity = append(it.Activity, o)
+			it.add(ObjectTypeActivity, o, nil, o.StartDateTime)
+		}
+	}
+	for _, o := range resp.RestaurantObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Restaurant = append(it.Restaurant, o)
+			it.add(ObjectTypeRestaurant, o, nil, o.DateTime)
+		}
+	}
+	for _, o := range resp.NoteObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Note = append(it.Note, o)
+			it.add(ObjectTypeNote, o, nil, o.DateTime)
+		}
+	}
+	for _, o := range resp.MapObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Map = append(it.Map, o)
+			it.add(ObjectTypeMap, o, nil, o.DateTime)
+		}
+	}
+	for _, o := range resp.DirectionsObject {
+		if it := byId[o.TripId]; it != nil {
+			it.Directions = append(it.Directions, o)
+			it.add(ObjectTypeDirections, o, nil, o.DateTime)
+		}
+	}
+	for i := range resp.WeatherObject {
+		o := &resp.WeatherObject[i]
+		if it := byId[o.TripId]; it != nil {
+			it.Weather = append(it.Weather, o)
+		}
+	}
+	for _, it := range result {
+		it.sort()
+	}
+	return result
+}
+
+// add adds an item to the itinerary.
+func (it *Itinerary) add(objectType string, object interface{}, segment interface{}, dt *DateTime) {
+	item := ItineraryItem{Type: objectType, Object: object, Segment: segment}
+	if t, err := dateTimeOf(dt); err == nil {
+		item.Time = t
+	}
+	it.Items = append(it.Items, item)
+}
+
+// sort sorts the items chronologically, keeping items without a time at the end.
+func (it *Itinerary) sort() {
+	sort.SliceStable(it.Items, func(i, j int) bool {
+		a, b := it.Items[i].Time, it.Items[j].Time
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+}
+
+// GetItinerary gets the trip with the given ID and all of its objects.
+func (t *TripIt) GetItinerary(ctx context.Context, tripId uint) (*Itinerary, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/id/%d/%s/true", ObjectTypeTrip, tripId, FilterIncludeObjects))
+	if err != nil {
+		return nil, err
+	}
+	id := strconv.FormatUint(uint64(tripId), 10)
+	for _, it := range NewItineraries(resp) {
+		if it.Trip.Id == id {
+			return it, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %d", ErrNotFound, ObjectTypeTrip, tripId)
+}
+
+// end returns the end time of the item in its local time zone, or its start time if it
+// has no end time.
+func (item *ItineraryItem) end() time.Time {
+	var dt *DateTime
+	switch s := item.Segment.(type) {
+	case *AirSegment:
+		dt = s.EndDateTime
+	case *RailSegment:
+		dt = s.EndDateTime
+	case *TransportSegment:
+		dt = s.EndDateTime
+	case *CruiseSegment:
+		dt = s.EndDateTime
+	case nil:
+		if o, ok := item.Object.(Object); ok {
+			if t, err := o.End(); err == nil {
+				return t
+			}
+		}
+	}
+	if t, err := dateTimeOf(dt); err == nil {
+		return t
+	}
+	return item.Time
+}
+
+// Start returns the start of the first item in the itinerary, in the time zone where it
+// takes place. It returns false if no item has a time.
+func (it *Itinerary) Start() (time.Time, bool) {
+	if len(it.Items) == 0 || it.Items[0].Time.IsZero() {
+		return time.Time{}, false
+	}
+	return it.Items[0].Time, true
+}
+
+// End returns the latest end of any item in the itinerary, for example the arrival of the
+// last flight, in the time zone where it takes place. It returns false if no item has a time.
+func (it *Itinerary) End() (time.Time, bool) {
+	var end time.Time
+	for i := range it.Items {
+		t := it.Items[i].end()
+		if !t.IsZero() && (end.IsZero() || t.After(end)) {
+			end = t
+		}
+	}
+	return end, !end.IsZero()
+}
+
+// Dates returns the local dates on which the trip starts and ends, using Start and End.
+// If the itinerary has no times, the trip's StartDate and EndDate are returned.
+func (it *Itinerary) Dates() (start, end Date) {
+	if t, ok := it.Start(); ok {
+		start = DateOf(t)
+	} else if it.Trip != nil {
+		start = it.Trip.StartDate
+	}
+	if t, ok := it.End(); ok {
+		end = DateOf(t)
+	} else if it.Trip != nil {
+		end = it.Trip.EndDate
+	}
+	return start, end
+}
+
+// Days returns the number of calendar days the trip spans, counting the first and last
+// days, or 0 if the dates are unknown.
+func (it *Itinerary) Days() int {
+	start, end := it.Dates()
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return start.DaysBetween(end) + 1
+}
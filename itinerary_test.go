@@ -0,0 +1,86 @@
+package tripit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const itineraryJSON = `{
+"Trip":[{"id":"1","display_name":"Cancun"},{"id":"2","display_name":"Other"}],
+"AirObject":{"id":"10","trip_id":"1","Segment":[
+	{"id":"11","StartDateTime":{"date":"2011-12-09","time":"08:00:00","utc_offset":"-05:00"},"start_airport_code":"JFK","end_airport_code":"CUN"},
+	{"id":"12","StartDateTime":{"date":"2011-12-27","time":"15:00:00","utc_offset":"-05:00"},"EndDateTime":{"date":"2011-12-27","time":"19:00:00","utc_offset":"-05:00"},"start_airport_code":"CUN","end_airport_code":"JFK"}
+]},
+"LodgingObject":{"id":"20","trip_id":"1","StartDateTime":{"date":"2011-12-09","time":"15:00:00","utc_offset":"-05:00"}},
+"RestaurantObject":{"id":"30","trip_id":"1","DateTime":{"date":"2011-12-10","time":"20:00:00","utc_offset":"-05:00"}},
+"NoteObject":{"id":"40","trip_id":"1"},
+"CarObject":{"id":"50","trip_id":"2"},
+"WeatherObject":{"trip_id":"1","date":"2011-12-09"}
+}`
+
+func TestNewItineraries(t *testing.T) {
+	var resp Response
+	if err := json.Unmarshal([]byte(itineraryJSON), &resp); err != nil {
+		t.Fatal(err)
+	}
+	its := NewItineraries(&resp)
+	if len(its) != 2 {
+		t.Fatalf("Expected 2 itineraries, got %d", len(its))
+	}
+	it := its[0]
+	if len(it.Air) != 1 || len(it.Lodging) != 1 || len(it.Restaurant) != 1 || len(it.Note) != 1 || len(it.Car) != 0 || len(it.Weather) != 1 {
+		t.Errorf("Objects not grouped correctly: %+v", it)
+	}
+	var order []string
+	for _, item := range it.Items {
+		switch s := item.Segment.(type) {
+		case *AirSegment:
+			order = append(order, s.Id)
+		case nil:
+			switch o := item.Object.(type) {
+			case *LodgingObject:
+				order = append(order, o.Id)
+			case *RestaurantObject:
+				order = append(order, o.Id)
+			case *NoteObject:
+				order = append(order, o.Id)
+			}
+		}
+	}
+	expected := []string{"11", "20", "30", "12", "40"}
+	if len(order) != len(expected) {
+		t.Fatalf("Unexpected items: %v", order)
+	}
+	for i := range order {
+		if order[i] != expected[i] {
+			t.Fatalf("Unexpected item order: %v", order)
+		}
+	}
+	if len(its[1].Car) != 1 || len(its[1].Items) != 1 {
+		t.Errorf("Car not grouped under second trip: %+v", its[1])
+	}
+}
+
+func TestGetItinerary(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(itineraryJSON))
+	}))
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+
+	it, err := c.GetItinerary(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/v1/get/trip/id/1/include_objects/true/format/json" {
+		t.Errorf("Unexpected path: %s", path)
+	}
+	if it.Trip.DisplayName != "Cancun" || len(it.Items) != 5 {
+		t.Errorf("Unexpected itinerary: %+v", it)
+	}
+}
@@ -0,0 +1,263 @@
+package tripit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// jsonStream decodes a JSON object from a reader one member at a time. Members that hold
+// an array for a OneOrMany field are decoded one element at a time, so only the largest
+// element, rather than the whole response, is held in memory at once. Other members are
+// decoded with encoding/json, so tag options and case-insensitive keys work as usual.
+type jsonStream struct {
+	r   *bufio.Reader
+	buf []byte // the current value; reused for every value
+	obj []byte // a single-member object for decoding one member into the struct
+}
+
+// decodeJSON decodes the JSON value in r into v. If v is a pointer to a struct, the value
+// is streamed; otherwise it is decoded with a json.Decoder.
+func decodeJSON(r io.Reader, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return json.NewDecoder(r).Decode(v)
+	}
+	s := &jsonStream{r: bufio.NewReader(r)}
+	return s.decodeStruct(rv.Elem())
+}
+
+// decodeStruct decodes an object into the struct v.
+func (s *jsonStream) decodeStruct(v reflect.Value) error {
+	c, err := s.peek()
+	if err != nil {
+		return err
+	}
+	if c != '{' {
+		b, err := s.value()
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, v.Addr().Interface())
+	}
+	s.r.ReadByte()
+	lists := oneOrManyFields(v.Type())
+	for first := true; ; first = false {
+		c, err := s.peek()
+		if err != nil {
+			return err
+		}
+		if c == '}' {
+			s.r.ReadByte()
+			return nil
+		}
+		if !first {
+			if err := s.expect(','); err != nil {
+				return err
+			}
+		}
+		key, err := s.value()
+		if err != nil {
+			return err
+		}
+		if len(key) < 2 || key[0] != '"' {
+			return fmt.Errorf("tripit: invalid JSON: expected an object key, found %q", key)
+		}
+		// Keys with escapes are decoded without streaming.
+		i, list := -1, false
+		if name := key[1 : len(key)-1]; bytes.IndexByte(name, '\\') < 0 {
+			i, list = lists[string(name)]
+		}
+		s.obj = append(append(s.obj[:0], '{'), key...)
+		if err := s.expect(':'); err != nil {
+			return err
+		}
+		if c, err := s.peek(); err != nil {
+			return err
+		} else if list && c == '[' {
+			if err := s.decodeList(v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := s.value()
+		if err != nil {
+			return err
+		}
+		s.obj = append(append(append(s.obj, ':'), b...), '}')
+		if err := json.Unmarshal(s.obj, v.Addr().Interface()); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeList decodes an array into the OneOrMany field v one element at a time. As with
+// OneOrMany.UnmarshalJSON, null elements of a list of pointers are dropped.
+func (s *jsonStream) decodeList(v reflect.Value) error {
+	s.r.ReadByte()
+	t := v.Type().Elem()
+	v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	for first := true; ; first = false {
+		c, err := s.peek()
+		if err != nil {
+			return err
+		}
+		if c == ']' {
+			s.r.ReadByte()
+			return nil
+		}
+		if !first {
+			if err := s.expect(','); err != nil {
+				return err
+			}
+		}
+		b, err := s.value()
+		if err != nil {
+			return err
+		}
+		if t.Kind() == reflect.Pointer {
+			if string(b) == "null" {
+				continue
+			}
+			e := reflect.New(t.Elem())
+			if err := json.Unmarshal(b, e.Interface()); err != nil {
+				return err
+			}
+			appendValue(v, e)
+			continue
+		}
+		e := reflect.New(t)
+		if err := json.Unmarshal(b, e.Interface()); err != nil {
+			return err
+		}
+		appendValue(v, e.Elem())
+	}
+}
+
+// appendValue appends e to the slice v. Unlike reflect.Append, it does not allocate for
+// every element.
+func appendValue(v, e reflect.Value) {
+	n := v.Len()
+	if n == v.Cap() {
+		v.Grow(1)
+	}
+	v.SetLen(n + 1)
+	v.Index(n).Set(e)
+}
+
+// peek returns the next byte that is not white space without consuming it.
+func (s *jsonStream) peek() (byte, error) {
+	for {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		if !isJSONSpace(c) {
+			s.r.UnreadByte()
+			return c, nil
+		}
+	}
+}
+
+// expect consumes the byte c, after any white space.
+func (s *jsonStream) expect(c byte) error {
+	b, err := s.peek()
+	if err != nil {
+		return err
+	}
+	if b != c {
+		return fmt.Errorf("tripit: invalid JSON: expected %q, found %q", c, b)
+	}
+	s.r.ReadByte()
+	return nil
+}
+
+// value reads the next JSON value, after any white space, without decoding it. The result
+// is only valid until the next call.
+func (s *jsonStream) value() ([]byte, error) {
+	c, err := s.peek()
+	if err != nil {
+		return nil, err
+	}
+	s.buf = s.buf[:0]
+	if c != '{' && c != '[' && c != '"' {
+		// A number, true, false or null, which ends at a delimiter.
+		for {
+			c, err := s.r.ReadByte()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			if isJSONSpace(c) || c == ',' || c == '}' || c == ']' || c == ':' {
+				s.r.UnreadByte()
+				break
+			}
+			s.buf = append(s.buf, c)
+		}
+		return s.buf, nil
+	}
+	depth := 0
+	inString, escaped := false, false
+	for {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		s.buf = append(s.buf, c)
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			escaped = c == '\\'
+			inString = c != '"'
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		}
+		if depth == 0 && !inString {
+			return s.buf, nil
+		}
+	}
+}
+
+// isJSONSpace returns true for the white space characters allowed between JSON tokens.
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF, since a value is incomplete.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// oneOrManyFields returns the indexes of the OneOrMany fields of a struct by JSON name.
+// Embedded structs are not searched, so their fields are decoded without streaming.
+func oneOrManyFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous || !f.IsExported() || !f.Type.Implements(oneOrManyType) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = i
+	}
+	return fields
+}
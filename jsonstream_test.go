@@ -0,0 +1,67 @@
+package tripit
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	for _, s := range []string{
+		string(largeResponse(3)),
+		` { "AirObject" : [ null , {"id":"1","notes":"\"@attributes\" [{\\\"} ]"} , null ] , "num_bytes" : "12" } `,
+		`{"airobject":[{"id":"1"}],"Trip":{"id":"2"},"Warning":null,"Error":"","Profile":[]}`,
+		`{"Trip":[{"id":"3"}],"timestamp":"1306543281","unknown":{"a":[1,2,{"b":true}]}}`,
+		`{}`,
+		`null`,
+	} {
+		var expected, got Response
+		if err := json.Unmarshal([]byte(s), &expected); err != nil {
+			t.Fatal(err)
+		}
+		if err := decodeJSON(strings.NewReader(s), &got); err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, got) {
+			t.Errorf("%s: expected %+v, got %+v", s, expected, got)
+		}
+	}
+
+	for _, s := range []string{``, `{`, `{"AirObject":[{"id":"1"}`, `{"AirObject" [] }`, `{"Trip":{"id":"1"} "x":1}`, `{1:2}`, `{"Trip":[{"id":}]}`} {
+		if err := decodeJSON(strings.NewReader(s), new(Response)); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+
+	var m map[string]string
+	if err := decodeJSON(strings.NewReader(`{"a":"b"}`), &m); err != nil || m["a"] != "b" {
+		t.Errorf("Unexpected map %v, %v", m, err)
+	}
+}
+
+func TestDecodeJSONStreams(t *testing.T) {
+	// The elements before a read error are already decoded, so the body is not buffered.
+	failure := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader(`{"AirObject":[{"id":"1"},{"id":"2"},`), &errReader{failure})
+	var resp Response
+	if err := decodeJSON(r, &resp); !errors.Is(err, failure) {
+		t.Errorf("Expected the read error, got %v", err)
+	}
+	if len(resp.AirObject) != 2 || resp.AirObject[1].Id != "2" {
+		t.Errorf("Unexpected air objects: %+v", resp.AirObject)
+	}
+}
+
+// errReader is a reader that always fails.
+type errReader struct {
+	err error
+}
+
+// Read returns the error.
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,134 @@
+package tripit
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// LayoverRules configures layover analysis.
+type LayoverRules struct {
+	MinConnectTime    time.Duration            // minimum connection time at airports not in Airports
+	Airports          map[string]time.Duration // minimum connection time by airport code, ignoring case and surrounding spaces
+	MinAirportChange  time.Duration            // minimum connection time when changing airports
+	MaxLayover        time.Duration            // longer gaps between flights are stopovers, not layovers; 0 means no limit
+	OvernightDuration time.Duration            // layovers at least this long are overnight even on the same date; 0 disables
+}
+
+// DefaultLayoverRules are conservative rules for domestic and international connections.
+var DefaultLayoverRules = LayoverRules{
+	MinConnectTime:    45 * time.Minute,
+	MinAirportChange:  3 * time.Hour,
+	MaxLayover:        24 * time.Hour,
+	OvernightDuration: 8 * time.Hour,
+}
+
+// Layover is the connection between two flights.
+type Layover struct {
+	Arrival          *AirSegment   // flight arriving at the connecting airport
+	Departure        *AirSegment   // next flight
+	Airport          string        // code of the airport the arriving flight lands at
+	DepartureAirport string        // code of the airport the next flight departs from
+	Duration         time.Duration // time between the arrival and the next departure
+	MinConnectTime   time.Duration // minimum connection time that applies to the layover
+	TooShort         bool          // Duration is less than MinConnectTime
+	AirportChange    bool          // the next flight departs from a different airport in the same city
+	Overnight        bool          // the layover spans local midnight or lasts at least OvernightDuration
+}
+
+// minConnectTime returns the minimum connection time for a connection from one airport to another.
+func (r *LayoverRules) minConnectTime(from, to string) time.Duration {
+	if from != to {
+		return r.MinAirportChange
+	}
+	if d, ok := r.Airports[from]; ok {
+		return d
+	}
+	for code, d := range r.Airports {
+		if airportCode(code) == from {
+			return d
+		}
+	}
+	return r.MinConnectTime
+}
+
+// airportCode normalizes an airport code for comparison.
+func airportCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Analyze returns the layovers between the given flights. The flights are sorted by
+// departure, and flights without departure or arrival times are ignored. Times are
+// compared in the time zones of the airports, so layovers are correct across zones.
+func (r *LayoverRules) Analyze(segments []*AirSegment) []Layover {
+	type flight struct {
+		seg        *AirSegment
+		start, end time.Time
+	}
+	var flights []flight
+	for _, s := range segments {
+		if s == nil {
+			continue
+		}
+		start, err := dateTimeOf(s.StartDateTime)
+		if err != nil {
+			continue
+		}
+		end, err := dateTimeOf(s.EndDateTime)
+		if err != nil {
+			continue
+		}
+		flights = append(flights, flight{s, start, end})
+	}
+	sort.SliceStable(flights, func(i, j int) bool {
+		return flights[i].start.Before(flights[j].start)
+	})
+
+	var result []Layover
+	for i := 1; i < len(flights); i++ {
+		in, out := flights[i-1], flights[i]
+		from, to := airportCode(in.seg.EndAirportCode), airportCode(out.seg.StartAirportCode)
+		d := out.start.Sub(in.end)
+		if r.MaxLayover > 0 && d > r.MaxLayover {
+			continue
+		}
+		if from != to && !sameCity(in.seg.EndCityName, out.seg.StartCityName) {
+			// Not a connection, for example a separate flight booked after ground travel.
+			continue
+		}
+		l := Layover{
+			Arrival:          in.seg,
+			Departure:        out.seg,
+			Airport:          from,
+			DepartureAirport: to,
+			Duration:         d,
+			MinConnectTime:   r.minConnectTime(from, to),
+			AirportChange:    from != to,
+			Overnight:        DateOf(in.end) != DateOf(out.start) || (r.OvernightDuration > 0 && d >= r.OvernightDuration),
+		}
+		l.TooShort = l.Duration < l.MinConnectTime
+		result = append(result, l)
+	}
+	return result
+}
+
+// AnalyzeAir returns the layovers between the segments of an air object.
+func (r *LayoverRules) AnalyzeAir(o *AirObject) []Layover {
+	return r.Analyze(o.Segment)
+}
+
+// AnalyzeItinerary returns the layovers between all flights in an itinerary, including
+// connections between flights booked in different air objects.
+func (r *LayoverRules) AnalyzeItinerary(it *Itinerary) []Layover {
+	var segments []*AirSegment
+	for _, o := range it.Air {
+		segments = append(segments, o.Segment...)
+	}
+	return r.Analyze(segments)
+}
+
+// sameCity returns true if both city names are known and equal, ignoring case.
+func sameCity(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	return a != "" && strings.EqualFold(a, b)
+}
@@ -0,0 +1,73 @@
+package tripit
+
+import (
+	"testing"
+	"time"
+)
+
+// flightSeg returns an air segment between two airports at the given local times.
+func flightSeg(from, fromCity, fromTz, start, to, toCity, toTz, end string) *AirSegment {
+	s := &AirSegment{StartAirportCode: from, StartCityName: fromCity, EndAirportCode: to, EndCityName: toCity}
+	s.StartDateTime = &DateTime{Date: start[:10], Time: start[11:], Timezone: fromTz}
+	s.EndDateTime = &DateTime{Date: end[:10], Time: end[11:], Timezone: toTz}
+	return s
+}
+
+func TestLayovers(t *testing.T) {
+	rules := DefaultLayoverRules
+	rules.Airports = map[string]time.Duration{"ord ": 50 * time.Minute} // codes are normalized
+	const (
+		pt = "America/Los_Angeles"
+		ct = "America/Chicago"
+		et = "America/New_York"
+		uk = "Europe/London"
+	)
+	air := &AirObject{Segment: AirSegmentPtrVector{
+		flightSeg(" ord", "Chicago", ct, "2011-12-09 12:30:00", "LGA", "New York", et, "2011-12-09 15:30:00"),
+		flightSeg("SFO", "San Francisco", pt, "2011-12-09 06:00:00", "ORD", "Chicago", ct, "2011-12-09 12:00:00"),
+		flightSeg("JFK", "New York", et, "2011-12-09 20:00:00", "LHR", "London", uk, "2011-12-10 08:00:00"),
+		flightSeg("LHR", "London", uk, "2011-12-10 21:30:00", "EDI", "Edinburgh", uk, "2011-12-10 23:00:00"),
+		flightSeg("EDI", "Edinburgh", uk, "2011-12-11 06:00:00", "DUB", "Dublin", "Europe/Dublin", "2011-12-11 07:00:00"),
+		flightSeg("DUB", "Dublin", "Europe/Dublin", "2011-12-14 09:00:00", "BOS", "Boston", et, "2011-12-14 11:00:00"),
+		{StartAirportCode: "BOS", EndAirportCode: "SFO"},
+	}}
+	layovers := rules.AnalyzeAir(air)
+	expected := []struct {
+		airport, departure string
+		duration           time.Duration
+		tooShort, change   bool
+		overnight          bool
+	}{
+		{"ORD", "ORD", 30 * time.Minute, true, false, false},
+		{"LGA", "JFK", 4*time.Hour + 30*time.Minute, false, true, false},
+		{"LHR", "LHR", 13*time.Hour + 30*time.Minute, false, false, true},
+		{"EDI", "EDI", 7 * time.Hour, false, false, true},
+	}
+	if len(layovers) != len(expected) {
+		t.Fatalf("Expected %d layovers, got %d: %+v", len(expected), len(layovers), layovers)
+	}
+	for i, e := range expected {
+		l := layovers[i]
+		if l.Airport != e.airport || l.DepartureAirport != e.departure || l.Duration != e.duration ||
+			l.TooShort != e.tooShort || l.AirportChange != e.change || l.Overnight != e.overnight {
+			t.Errorf("Layover %d: expected %+v, got %+v", i, e, l)
+		}
+	}
+	if layovers[0].MinConnectTime != 50*time.Minute || layovers[1].MinConnectTime != 3*time.Hour {
+		t.Errorf("Unexpected minimum connection times: %v, %v", layovers[0].MinConnectTime, layovers[1].MinConnectTime)
+	}
+
+	// Connections across air objects are found in an itinerary.
+	it := &Itinerary{Air: []*AirObject{
+		{Segment: AirSegmentPtrVector{air.Segment[1]}},
+		{Segment: AirSegmentPtrVector{air.Segment[0]}},
+	}}
+	if l := rules.AnalyzeItinerary(it); len(l) != 1 || !l[0].TooShort {
+		t.Errorf("Unexpected itinerary layovers: %+v", l)
+	}
+
+	// Flights to a different city are not connections.
+	if l := rules.Analyze([]*AirSegment{air.Segment[1], flightSeg("MDW", "Midway", ct, "2011-12-09 14:00:00", "DEN", "Denver", "America/Denver", "2011-12-09 16:00:00")}); len(l) != 0 {
+		t.Errorf("Expected no layovers, got %+v", l)
+	}
+}
@@ -0,0 +1,221 @@
+package tripit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FlightEvent is a change in the status of a flight, detected by a FlightMonitor. The
+// events are *GateChanged, *Delayed, *Cancelled, *Diverted, *ConnectionAtRisk and
+// *BaggageClaimAssigned.
+type FlightEvent interface {
+	Flight() *FlightChange
+}
+
+// FlightChange identifies the flight an event is about and holds its flight status
+// before and after the change.
+type FlightChange struct {
+	Air      *AirObject
+	Segment  *AirSegment
+	Previous *FlightStatus // status from the previous poll; empty the first time the flight is seen
+	Current  *FlightStatus // status from the latest poll
+}
+
+// Flight returns the change.
+func (c *FlightChange) Flight() *FlightChange {
+	return c
+}
+
+// GateChanged is sent when a departure or arrival gate is assigned or changes.
+type GateChanged struct {
+	FlightChange
+	Arrival  bool   // true for the arrival gate, false for the departure gate
+	From, To string // previous and new gate; From is empty when the gate is first assigned
+}
+
+// Delayed is sent when the estimated departure or arrival becomes later than scheduled,
+// or later than previously estimated.
+type Delayed struct {
+	FlightChange
+	Arrival bool          // true if the arrival is delayed, false for the departure
+	By      time.Duration // delay compared with the scheduled time
+}
+
+// Cancelled is sent when a flight is cancelled.
+type Cancelled struct {
+	FlightChange
+}
+
+// Diverted is sent when a flight is diverted.
+type Diverted struct {
+	FlightChange
+	To string // code of the airport the flight is diverted to, if known
+}
+
+// ConnectionAtRisk is sent when TripIt reports that the connection after a flight is at risk.
+type ConnectionAtRisk struct {
+	FlightChange
+}
+
+// BaggageClaimAssigned is sent when the baggage claim is assigned or changes.
+type BaggageClaimAssigned struct {
+	FlightChange
+	BaggageClaim string
+}
+
+// FlightEventHandler is called for each event detected by a FlightMonitor.
+type FlightEventHandler func(FlightEvent)
+
+// FlightMonitor polls the user's upcoming flights and sends events to its handlers when
+// their flight status changes. The first time a flight is seen, its status is compared
+// with an empty status, so that conditions such as an assigned gate or a delay are
+// reported once.
+type FlightMonitor struct {
+	client   *TripIt
+	interval time.Duration
+
+	mu       sync.Mutex
+	handlers []FlightEventHandler
+	last     map[string]FlightStatus // previous status by segment key
+}
+
+// MinFlightMonitorInterval is the shortest interval at which a FlightMonitor polls TripIt.
+const MinFlightMonitorInterval = time.Minute
+
+// NewFlightMonitor creates a monitor that uses the given client and polls at the given
+// interval. Intervals shorter than MinFlightMonitorInterval, including zero and negative
+// ones, are raised to MinFlightMonitorInterval.
+func NewFlightMonitor(client *TripIt, interval time.Duration) *FlightMonitor {
+	if interval < MinFlightMonitorInterval {
+		interval = MinFlightMonitorInterval
+	}
+	return &FlightMonitor{client: client, interval: interval, last: make(map[string]FlightStatus)}
+}
+
+// Handle registers a handler for flight events. Handlers are called in the order they
+// were registered, from the goroutine that polls.
+func (m *FlightMonitor) Handle(h FlightEventHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, h)
+}
+
+// Run polls until the context is done, and then returns the context's error. Errors from
+// individual polls are passed to onError, which may be nil.
+func (m *FlightMonitor) Run(ctx context.Context, onError func(error)) error {
+	for {
+		if err := m.Poll(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		if err := sleep(ctx, m.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Poll gets the user's upcoming air objects once, compares the status of each flight that
+// has not yet arrived with the previous poll, and sends the resulting events to the handlers.
+func (m *FlightMonitor) Poll(ctx context.Context) error {
+	resp, err := m.client.ListAll(ctx, &ObjectListFilter{Type: ObjectTypeAir})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	m.mu.Lock()
+	var events []FlightEvent
+	seen := make(map[string]bool)
+	for _, air := range resp.AirObject {
+		if air == nil {
+			continue
+		}
+		for i, seg := range air.Segment {
+			if seg == nil || seg.Status == nil || arrived(seg, now) {
+				continue
+			}
+			key := seg.Id
+			if key == "" {
+				key = fmt.Sprintf("%s/%d", air.Id, i)
+			}
+			seen[key] = true
+			prev := m.last[key]
+			cur := *seg.Status
+			m.last[key] = cur
+			events = append(events, diffFlightStatus(FlightChange{Air: air, Segment: seg, Previous: &prev, Current: &cur})...)
+		}
+	}
+	for key := range m.last {
+		if !seen[key] {
+			delete(m.last, key)
+		}
+	}
+	handlers := m.handlers
+	m.mu.Unlock()
+
+	for _, e := range events {
+		for _, h := range handlers {
+			h(e)
+		}
+	}
+	return nil
+}
+
+// arrived returns true if the segment's estimated or scheduled arrival is before now.
+func arrived(seg *AirSegment, now time.Time) bool {
+	dt := seg.EndDateTime
+	if seg.Status.EstimatedArrivalDateTime != nil {
+		dt = seg.Status.EstimatedArrivalDateTime
+	}
+	t, err := dateTimeOf(dt)
+	return err == nil && t.Before(now)
+}
+
+// diffFlightStatus returns the events for the change from c.Previous to c.Current.
+func diffFlightStatus(c FlightChange) []FlightEvent {
+	var events []FlightEvent
+	prev, cur := c.Previous, c.Current
+	if cur.DepartureGate != "" && cur.DepartureGate != prev.DepartureGate {
+		events = append(events, &GateChanged{FlightChange: c, From: prev.DepartureGate, To: cur.DepartureGate})
+	}
+	if cur.ArrivalGate != "" && cur.ArrivalGate != prev.ArrivalGate {
+		events = append(events, &GateChanged{FlightChange: c, Arrival: true, From: prev.ArrivalGate, To: cur.ArrivalGate})
+	}
+	if d := delay(cur.ScheduledDepartureDateTime, cur.EstimatedDepartureDateTime); d > 0 &&
+		d > delay(prev.ScheduledDepartureDateTime, prev.EstimatedDepartureDateTime) {
+		events = append(events, &Delayed{FlightChange: c, By: d})
+	}
+	if d := delay(cur.ScheduledArrivalDateTime, cur.EstimatedArrivalDateTime); d > 0 &&
+		d > delay(prev.ScheduledArrivalDateTime, prev.EstimatedArrivalDateTime) {
+		events = append(events, &Delayed{FlightChange: c, Arrival: true, By: d})
+	}
+	if cur.FlightStatus == FlightStatusCancelled && prev.FlightStatus != FlightStatusCancelled {
+		events = append(events, &Cancelled{FlightChange: c})
+	}
+	if (cur.FlightStatus == FlightStatusDiverted && prev.FlightStatus != FlightStatusDiverted) ||
+		(cur.DivertedAirportCode != "" && cur.DivertedAirportCode != prev.DivertedAirportCode) {
+		events = append(events, &Diverted{FlightChange: c, To: cur.DivertedAirportCode})
+	}
+	if cur.IsConnectionAtRisk && !prev.IsConnectionAtRisk {
+		events = append(events, &ConnectionAtRisk{FlightChange: c})
+	}
+	if cur.BaggageClaim != "" && cur.BaggageClaim != prev.BaggageClaim {
+		events = append(events, &BaggageClaimAssigned{FlightChange: c, BaggageClaim: cur.BaggageClaim})
+	}
+	return events
+}
+
+// delay returns how much later the estimated time is than the scheduled time, or 0 if
+// either time is unknown.
+func delay(scheduled, estimated *DateTime) time.Duration {
+	s, err := dateTimeOf(scheduled)
+	if err != nil {
+		return 0
+	}
+	e, err := dateTimeOf(estimated)
+	if err != nil {
+		return 0
+	}
+	return e.Sub(s)
+}
@@ -0,0 +1,110 @@
+package tripit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightMonitor(t *testing.T) {
+	statuses := []string{
+		`{"flight_status":"301","departure_gate":"A1",` +
+			`"ScheduledDepartureDateTime":{"date":"2099-06-01","time":"08:00:00","timezone":"America/New_York"},` +
+			`"EstimatedDepartureDateTime":{"date":"2099-06-01","time":"08:00:00","timezone":"America/New_York"}}`,
+		`{"flight_status":"401","departure_gate":"A2","is_connection_at_risk":"true","baggage_claim":"5",` +
+			`"ScheduledDepartureDateTime":{"date":"2099-06-01","time":"08:00:00","timezone":"America/New_York"},` +
+			`"EstimatedDepartureDateTime":{"date":"2099-06-01","time":"08:45:00","timezone":"America/New_York"}}`,
+		`{"flight_status":"400","departure_gate":"A2","is_connection_at_risk":"true","baggage_claim":"5"}`,
+	}
+	var poll int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/list/object/page_num/1/type/air/format/json" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		n := atomic.AddInt32(&poll, 1) - 1
+		w.Write([]byte(`{"AirObject":{"id":"1","Segment":[` +
+			`{"id":"10","EndDateTime":{"date":"2000-01-01","time":"10:00:00","timezone":"UTC"},"Status":{"departure_gate":"Z9"}},` +
+			`{"id":"11","marketing_flight_number":"100","EndDateTime":{"date":"2099-06-01","time":"11:00:00","timezone":"America/New_York"},` +
+			`"Status":` + statuses[n] + `}]}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+	m := NewFlightMonitor(c, time.Minute)
+	var events []FlightEvent
+	m.Handle(func(e FlightEvent) { events = append(events, e) })
+	ctx := context.Background()
+
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	if g, ok := events[0].(*GateChanged); !ok || g.From != "" || g.To != "A1" || g.Arrival || g.Segment.MarketingFlightNumber != "100" {
+		t.Errorf("Unexpected event: %#v", events[0])
+	}
+
+	events = nil
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(events))
+	}
+	if g, ok := events[0].(*GateChanged); !ok || g.From != "A1" || g.To != "A2" {
+		t.Errorf("Unexpected gate change: %#v", events[0])
+	}
+	if d, ok := events[1].(*Delayed); !ok || d.By != 45*time.Minute || d.Arrival {
+		t.Errorf("Unexpected delay: %#v", events[1])
+	}
+	if _, ok := events[2].(*ConnectionAtRisk); !ok {
+		t.Errorf("Unexpected event: %#v", events[2])
+	}
+	if b, ok := events[3].(*BaggageClaimAssigned); !ok || b.BaggageClaim != "5" || b.Flight().Previous.BaggageClaim != "" {
+		t.Errorf("Unexpected baggage claim: %#v", events[3])
+	}
+
+	events = nil
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	if c, ok := events[0].(*Cancelled); !ok || c.Current.FlightStatus != FlightStatusCancelled || c.Previous.FlightStatus != FlightStatusDelayed {
+		t.Errorf("Unexpected event: %#v", events[0])
+	}
+}
+
+func TestDiffFlightStatus(t *testing.T) {
+	events := diffFlightStatus(FlightChange{
+		Previous: &FlightStatus{ArrivalGate: "B1"},
+		Current:  &FlightStatus{ArrivalGate: "B2", FlightStatus: FlightStatusDiverted, DivertedAirportCode: "BOS"},
+	})
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if g, ok := events[0].(*GateChanged); !ok || !g.Arrival || g.To != "B2" {
+		t.Errorf("Unexpected event: %#v", events[0])
+	}
+	if d, ok := events[1].(*Diverted); !ok || d.To != "BOS" {
+		t.Errorf("Unexpected event: %#v", events[1])
+	}
+}
+
+func TestFlightMonitorInterval(t *testing.T) {
+	for in, expected := range map[time.Duration]time.Duration{
+		0:                MinFlightMonitorInterval,
+		-time.Second:     MinFlightMonitorInterval,
+		time.Millisecond: MinFlightMonitorInterval,
+		5 * time.Minute:  5 * time.Minute,
+	} {
+		if m := NewFlightMonitor(nil, in); m.interval != expected {
+			t.Errorf("%v: expected interval %v, got %v", in, expected, m.interval)
+		}
+	}
+}
@@ -0,0 +1,581 @@
+package tripit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoDateTime is returned when an object doesn't have the requested date and time.
+var ErrNoDateTime = errors.New("tripit: no date and time")
+
+// Object is implemented by the reservation objects: AirObject, LodgingObject, CarObject,
+// RailObject, TransportObject, CruiseObject, RestaurantObject and ActivityObject. It allows
+// reservations to be filtered, sorted and rendered without a type switch.
+type Object interface {
+	ObjectType() string        // object type, one of the ObjectType constants
+	ID() string                // object ID
+	TripID() string            // ID of the trip containing the object
+	Name() string              // display name
+	Start() (time.Time, error) // start of the reservation
+	End() (time.Time, error)   // end of the reservation; the start for objects without an end
+	Booking() Booking          // booking and supplier information
+}
+
+// Booking contains the booking and supplier information common to all reservations.
+type Booking struct {
+	Date                 Date
+	Rate                 string
+	SiteConfNum          string
+	SiteName             string
+	SitePhone            string
+	SiteUrl              string
+	RecordLocator        string
+	SupplierConfNum      string
+	SupplierContact      string
+	SupplierEmailAddress string
+	SupplierName         string
+	SupplierPhone        string
+	SupplierUrl          string
+	IsPurchased          bool
+	Notes                string
+	Restrictions         string
+	TotalCost            string
+}
+
+// dateTimeOf converts dt to a time.Time, returning ErrNoDateTime if it is nil.
+func dateTimeOf(dt *DateTime) (time.Time, error) {
+	if dt == nil {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dt.GetTime()
+}
+
+// NewRequest creates a Request containing the given object, for use with Create or Replace.
+// It returns an error if the object is not one of the reservation types.
+func NewRequest(o Object) (*Request, error) {
+	r := new(Request)
+	switch v := o.(type) {
+	case *AirObject:
+		r.AirObject = v
+	case *LodgingObject:
+		r.LodgingObject = v
+	case *CarObject:
+		r.CarObject = v
+	case *RailObject:
+		r.RailObject = v
+	case *TransportObject:
+		r.TransportObject = v
+	case *CruiseObject:
+		r.CruiseObject = v
+	case *RestaurantObject:
+		r.RestaurantObject = v
+	case *ActivityObject:
+		r.ActivityObject = v
+	default:
+		return nil, fmt.Errorf("tripit: unsupported object type %T", o)
+	}
+	return r, nil
+}
+
+// Objects returns all of the reservation objects in the response.
+func (r *Response) Objects() []Object {
+	var result []Object
+	for _, o := range r.AirObject {
+		result = append(result, o)
+	}
+	for _, o := range r.LodgingObject {
+		result = append(result, o)
+	}
+	for _, o := range r.CarObject {
+		result = append(result, o)
+	}
+	for _, o := range r.RailObject {
+		result = append(result, o)
+	}
+	for _, o := range r.TransportObject {
+		result = append(result, o)
+	}
+	for _, o := range r.CruiseObject {
+		result = append(result, o)
+	}
+	for _, o := range r.RestaurantObject {
+		result = append(result, o)
+	}
+	for _, o := range r.ActivityObject {
+		result = append(result, o)
+	}
+	return result
+}
+
+// ObjectType returns ObjectTypeAir.
+func (r *AirObject) ObjectType() string {
+	return ObjectTypeAir
+}
+
+// ID returns the object ID.
+func (r *AirObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *AirObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *AirObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time of the first segment.
+func (r *AirObject) Start() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[0].StartDateTime)
+}
+
+// End returns the end time of the last segment.
+func (r *AirObject) End() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[len(r.Segment)-1].EndDateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *AirObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeLodging.
+func (r *LodgingObject) ObjectType() string {
+	return ObjectTypeLodging
+}
+
+// ID returns the object ID.
+func (r *LodgingObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *LodgingObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *LodgingObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time.
+func (r *LodgingObject) Start() (time.Time, error) {
+	return dateTimeOf(r.StartDateTime)
+}
+
+// End returns the end time.
+func (r *LodgingObject) End() (time.Time, error) {
+	return dateTimeOf(r.EndDateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *LodgingObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeCar.
+func (r *CarObject) ObjectType() string {
+	return ObjectTypeCar
+}
+
+// ID returns the object ID.
+func (r *CarObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *CarObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *CarObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time.
+func (r *CarObject) Start() (time.Time, error) {
+	return dateTimeOf(r.StartDateTime)
+}
+
+// End returns the end time.
+func (r *CarObject) End() (time.Time, error) {
+	return dateTimeOf(r.EndDateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *CarObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeRail.
+func (r *RailObject) ObjectType() string {
+	return ObjectTypeRail
+}
+
+// ID returns the object ID.
+func (r *RailObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *RailObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *RailObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time of the first segment.
+func (r *RailObject) Start() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[0].StartDateTime)
+}
+
+// End returns the end time of the last segment.
+func (r *RailObject) End() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[len(r.Segment)-1].EndDateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *RailObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeTransport.
+func (r *TransportObject) ObjectType() string {
+	return ObjectTypeTransport
+}
+
+// ID returns the object ID.
+func (r *TransportObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *TransportObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *TransportObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time of the first segment.
+func (r *TransportObject) Start() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[0].StartDateTime)
+}
+
+// End returns the end time of the last segment.
+func (r *TransportObject) End() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[len(r.Segment)-1].EndDateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *TransportObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeCruise.
+func (r *CruiseObject) ObjectType() string {
+	return ObjectTypeCruise
+}
+
+// ID returns the object ID.
+func (r *CruiseObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *CruiseObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *CruiseObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time of the first segment.
+func (r *CruiseObject) Start() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[0].StartDateTime)
+}
+
+// End returns the end time of the last segment.
+func (r *CruiseObject) End() (time.Time, error) {
+	if len(r.Segment) == 0 {
+		return time.Time{}, ErrNoDateTime
+	}
+	return dateTimeOf(r.Segment[len(r.Segment)-1].EndDateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *CruiseObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeRestaurant.
+func (r *RestaurantObject) ObjectType() string {
+	return ObjectTypeRestaurant
+}
+
+// ID returns the object ID.
+func (r *RestaurantObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *RestaurantObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *RestaurantObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the time of the reservation.
+func (r *RestaurantObject) Start() (time.Time, error) {
+	return dateTimeOf(r.DateTime)
+}
+
+// End returns the time of the reservation, since restaurant reservations have no end time.
+func (r *RestaurantObject) End() (time.Time, error) {
+	return dateTimeOf(r.DateTime)
+}
+
+// Booking returns the booking and supplier information.
+func (r *RestaurantObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
+
+// ObjectType returns ObjectTypeActivity.
+func (r *ActivityObject) ObjectType() string {
+	return ObjectTypeActivity
+}
+
+// ID returns the object ID.
+func (r *ActivityObject) ID() string {
+	return r.Id
+}
+
+// TripID returns the ID of the trip containing the object.
+func (r *ActivityObject) TripID() string {
+	return r.TripId
+}
+
+// Name returns the display name.
+func (r *ActivityObject) Name() string {
+	return r.DisplayName
+}
+
+// Start returns the start time.
+func (r *ActivityObject) Start() (time.Time, error) {
+	return dateTimeOf(r.StartDateTime)
+}
+
+// End returns EndDateTime if it is set. Otherwise it is EndTime on the start date, or on
+// the following day if EndTime is before the start time. If EndTime is empty, the start
+// time is returned.
+func (r *ActivityObject) End() (time.Time, error) {
+	if r.EndDateTime != nil {
+		return dateTimeOf(r.EndDateTime)
+	}
+	if r.StartDateTime == nil {
+		return time.Time{}, ErrNoDateTime
+	}
+	if r.EndTime == "" {
+		return r.StartDateTime.GetTime()
+	}
+	start, err := r.StartDateTime.GetTime()
+	if err != nil {
+		return start, err
+	}
+	dt := *r.StartDateTime
+	dt.Time = r.EndTime
+	end, err := dt.GetTime()
+	if err != nil {
+		return end, err
+	}
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, nil
+}
+
+// Booking returns the booking and supplier information.
+func (r *ActivityObject) Booking() Booking {
+	return Booking{
+		Date:                 r.BookingDate,
+		Rate:                 r.BookingRate,
+		SiteConfNum:          r.BookingSiteConfNum,
+		SiteName:             r.BookingSiteName,
+		SitePhone:            r.BookingSitePhone,
+		SiteUrl:              r.BookingSiteUrl,
+		RecordLocator:        r.RecordLocator,
+		SupplierConfNum:      r.SupplierConfNum,
+		SupplierContact:      r.SupplierContact,
+		SupplierEmailAddress: r.SupplierEmailAddress,
+		SupplierName:         r.SupplierName,
+		SupplierPhone:        r.SupplierPhone,
+		SupplierUrl:          r.SupplierUrl,
+		IsPurchased:          r.IsPurchased,
+		Notes:                r.Notes,
+		Restrictions:         r.Restrictions,
+		TotalCost:            r.TotalCost,
+	}
+}
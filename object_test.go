@@ -0,0 +1,75 @@
+package tripit
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObjects(t *testing.T) {
+	var resp Response
+	if err := json.Unmarshal([]byte(itineraryJSON), &resp); err != nil {
+		t.Fatal(err)
+	}
+	objs := resp.Objects()
+	if len(objs) != 4 {
+		t.Fatalf("Expected 4 objects, got %d", len(objs))
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, errA := objs[i].Start()
+		b, errB := objs[j].Start()
+		if errA != nil || errB != nil {
+			return errA == nil
+		}
+		return a.Before(b)
+	})
+	var types []string
+	for _, o := range objs {
+		types = append(types, o.ObjectType())
+	}
+	if types[0] != ObjectTypeAir || types[1] != ObjectTypeLodging || types[2] != ObjectTypeRestaurant || types[3] != ObjectTypeCar {
+		t.Errorf("Unexpected order: %v", types)
+	}
+
+	air := objs[0]
+	end, err := air.End()
+	if err != nil || end.Day() != 27 || air.ID() != "10" || air.TripID() != "1" {
+		t.Errorf("Unexpected air object: %v %v %v", air, end, err)
+	}
+	if _, err := objs[3].Start(); !errors.Is(err, ErrNoDateTime) {
+		t.Errorf("Expected ErrNoDateTime, got %v", err)
+	}
+}
+
+func TestActivityEnd(t *testing.T) {
+	a := &ActivityObject{
+		StartDateTime: &DateTime{Date: "2011-12-10", Time: "22:00:00", UtcOffset: "-05:00"},
+		EndTime:       "01:30:00",
+		SupplierName:  "Club",
+	}
+	start, _ := a.Start()
+	end, err := a.End()
+	if err != nil || end.Sub(start) != 210*time.Minute {
+		t.Errorf("Unexpected end time: %v, %v", end, err)
+	}
+	a.EndDateTime = &DateTime{Date: "2011-12-12", Time: "02:00:00", UtcOffset: "-05:00"}
+	if end, err := a.End(); err != nil || end.Sub(start) != 28*time.Hour {
+		t.Errorf("Unexpected end date and time: %v, %v", end, err)
+	}
+	if a.Booking().SupplierName != "Club" {
+		t.Errorf("Unexpected booking: %+v", a.Booking())
+	}
+	r, err := NewRequest(a)
+	if err != nil || r.ActivityObject != a || r.AirObject != nil {
+		t.Errorf("Unexpected request: %+v, %v", r, err)
+	}
+
+	// Other implementations of Object have no request field.
+	type otherObject struct{ *ActivityObject }
+	if r, err := NewRequest(otherObject{a}); r != nil || err == nil || !strings.Contains(err.Error(), "otherObject") {
+		t.Errorf("Expected an error, got %+v, %v", r, err)
+	}
+}
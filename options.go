@@ -0,0 +1,117 @@
+package tripit
+
+import (
+	"log"
+	"net/http"
+)
+
+// Option configures a TripIt object created with NewClient.
+type Option func(*TripIt)
+
+// Middleware wraps an http.RoundTripper, for example to add logging, metrics or tracing
+// to every request made by the client.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// NewClient creates a new TripIt object using the given authorization object. By default
+// the client uses ApiUrl, ApiVersion, JsonCodec and http.DefaultClient; use options to change them.
+func NewClient(creds Authorizable, opts ...Option) *TripIt {
+	t := &TripIt{baseUrl: ApiUrl, version: ApiVersion, httpClient: http.DefaultClient, credentials: creds, codec: JsonCodec}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if len(t.middleware) > 0 {
+		// Copy the client so that the caller's client is not modified.
+		c := *t.httpClient
+		rt := c.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		for i := len(t.middleware) - 1; i >= 0; i-- {
+			rt = t.middleware[i](rt)
+		}
+		c.Transport = rt
+		t.httpClient = &c
+	}
+	return t
+}
+
+// WithApiUrl sets the base URL of the TripIt API.
+func WithApiUrl(apiUrl string) Option {
+	return func(t *TripIt) {
+		t.baseUrl = apiUrl
+	}
+}
+
+// WithApiVersion sets the TripIt API version.
+func WithApiVersion(apiVersion string) Option {
+	return func(t *TripIt) {
+		t.version = apiVersion
+	}
+}
+
+// WithHttpClient sets the HTTP client used to make requests.
+func WithHttpClient(client *http.Client) Option {
+	return func(t *TripIt) {
+		t.httpClient = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(t *TripIt) {
+		t.userAgent = userAgent
+	}
+}
+
+// WithLogger sets a logger that receives one line for each HTTP request attempt.
+func WithLogger(logger *log.Logger) Option {
+	return func(t *TripIt) {
+		t.logger = logger
+	}
+}
+
+// WithRetryPolicy sets the retry policy. See SetRetryPolicy.
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(t *TripIt) {
+		t.SetRetryPolicy(p)
+	}
+}
+
+// WithRateLimiter sets the rate limiter. See SetRateLimiter.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(t *TripIt) {
+		t.SetRateLimiter(l)
+	}
+}
+
+// WithResponseErrors controls whether responses containing Error entries are returned as
+// an *APIError. See SetResponseErrors.
+func WithResponseErrors(enable bool) Option {
+	return func(t *TripIt) {
+		t.SetResponseErrors(enable)
+	}
+}
+
+// WithCodec sets the wire format used to talk to TripIt. The default is JsonCodec.
+func WithCodec(c Codec) Option {
+	return func(t *TripIt) {
+		t.codec = c
+	}
+}
+
+// WithMaxBodySize sets the largest response body the client reads, in bytes. Larger
+// responses fail with ErrBodyTooLarge. Zero uses DefaultMaxBodySize and a negative size
+// removes the limit.
+func WithMaxBodySize(n int64) Option {
+	return func(t *TripIt) {
+		t.maxBodySize = n
+	}
+}
+
+// WithMiddleware adds middleware that wraps the HTTP client's transport. Middleware is
+// applied in order, so the first middleware given sees each request first.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(t *TripIt) {
+		t.middleware = append(t.middleware, mw...)
+	}
+}
@@ -0,0 +1,61 @@
+package tripit
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClient(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"timestamp":"1306543281"}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	var buf bytes.Buffer
+	client := srv.Client()
+	transport := client.Transport
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"},
+		WithApiUrl(srv.URL),
+		WithApiVersion("v1"),
+		WithHttpClient(client),
+		WithUserAgent("go-tripit-test"),
+		WithLogger(log.New(&buf, "", 0)),
+		WithMiddleware(mw("outer"), mw("inner")),
+	)
+	if _, err := c.Get(ObjectTypeTrip, 1); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("Unexpected middleware order: %v", order)
+	}
+	if userAgent != "go-tripit-test" {
+		t.Errorf("Unexpected user agent: %q", userAgent)
+	}
+	if !strings.Contains(buf.String(), "/v1/get/trip/id/1/format/json: 200 OK") {
+		t.Errorf("Unexpected log output: %q", buf.String())
+	}
+	if client.Transport != transport {
+		t.Error("The caller's HTTP client was modified")
+	}
+}
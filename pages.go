@@ -0,0 +1,176 @@
+package tripit
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// PageOption configures how paginated list results are fetched.
+type PageOption func(*pageConfig)
+
+type pageConfig struct {
+	pageSize int
+	prefetch bool
+}
+
+// WithPageSize sets the number of items to request per page.
+func WithPageSize(n int) PageOption {
+	return func(c *pageConfig) {
+		c.pageSize = n
+	}
+}
+
+// WithPrefetch controls whether the next page is fetched in the background while the
+// caller processes the current page.
+func WithPrefetch(enable bool) PageOption {
+	return func(c *pageConfig) {
+		c.prefetch = enable
+	}
+}
+
+type pageResult struct {
+	resp *Response
+	err  error
+}
+
+// maxPage returns the last page number reported by TripIt, or 0 if pagination is not active.
+func (r *Response) maxPage() int {
+	n, err := strconv.Atoi(r.MaxPage.String())
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// Pages returns an iterator over the pages of a filtered list. Pages are fetched lazily,
+// starting with the first page, until the last page reported by TripIt in MaxPage has been
+// returned. The filter's page number is ignored. Iteration stops after the first error.
+// supports: trip, object
+func (t *TripIt) Pages(ctx context.Context, f ListFilter, opts ...PageOption) iter.Seq2[*Response, error] {
+	var cfg pageConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(yield func(*Response, error) bool) {
+		filterParms, err := f.Params()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		list := func(page int) (*Response, error) {
+			parms := make(map[string]string, len(filterParms)+2)
+			for k, v := range filterParms {
+				parms[k] = v
+			}
+			parms[FilterPageNum] = strconv.Itoa(page)
+			if cfg.pageSize > 0 {
+				parms[FilterPageSize] = strconv.Itoa(cfg.pageSize)
+			}
+			return t.ListContext(ctx, f.ListType(), parms)
+		}
+
+		var pending chan pageResult
+		for page := 1; ; page++ {
+			var r pageResult
+			if pending != nil {
+				r = <-pending
+				pending = nil
+			} else {
+				r.resp, r.err = list(page)
+			}
+			if r.err != nil {
+				yield(nil, r.err)
+				return
+			}
+			more := page < r.resp.maxPage()
+			if more && cfg.prefetch {
+				pending = make(chan pageResult, 1)
+				go func(page int) {
+					resp, err := list(page)
+					pending <- pageResult{resp, err}
+				}(page + 1)
+			}
+			if !yield(r.resp, nil) || !more {
+				return
+			}
+		}
+	}
+}
+
+// Trips returns an iterator over all trips matching the filter, fetching successive pages
+// as needed. A nil filter lists all upcoming trips. See Pages.
+func (t *TripIt) Trips(ctx context.Context, f *TripListFilter, opts ...PageOption) iter.Seq2[*Trip, error] {
+	if f == nil {
+		f = new(TripListFilter)
+	}
+	return func(yield func(*Trip, error) bool) {
+		for resp, err := range t.Pages(ctx, f, opts...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, trip := range resp.Trip {
+				if !yield(trip, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ListAll fetches every page of a filtered list and merges the results into a single
+// Response. See Pages.
+func (t *TripIt) ListAll(ctx context.Context, f ListFilter, opts ...PageOption) (*Response, error) {
+	var result *Response
+	for resp, err := range t.Pages(ctx, f, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = resp
+		} else {
+			result.merge(resp)
+		}
+	}
+	return result, nil
+}
+
+// merge appends the objects from o to r.
+func (r *Response) merge(o *Response) {
+	r.Error = append(r.Error, o.Error...)
+	r.Warning = append(r.Warning, o.Warning...)
+	r.Trip = append(r.Trip, o.Trip...)
+	r.ActivityObject = append(r.ActivityObject, o.ActivityObject...)
+	r.AirObject = append(r.AirObject, o.AirObject...)
+	r.CarObject = append(r.CarObject, o.CarObject...)
+	r.CruiseObject = append(r.CruiseObject, o.CruiseObject...)
+	r.DirectionsObject = append(r.DirectionsObject, o.DirectionsObject...)
+	r.LodgingObject = append(r.LodgingObject, o.LodgingObject...)
+	r.MapObject = append(r.MapObject, o.MapObject...)
+	r.NoteObject = append(r.NoteObject, o.NoteObject...)
+	r.RailObject = append(r.RailObject, o.RailObject...)
+	r.RestaurantObject = append(r.RestaurantObject, o.RestaurantObject...)
+	r.TransportObject = append(r.TransportObject, o.TransportObject...)
+	r.WeatherObject = append(r.WeatherObject, o.WeatherObject...)
+	r.PointsProgram = append(r.PointsProgram, o.PointsProgram...)
+	if !r.indexCurrent() {
+		r.indexProfiles()
+	}
+	for _, p := range o.Profile {
+		ref := p.Attributes.Ref
+		if _, ok := r.profiles[ref]; ref != "" && ok {
+			continue
+		}
+		r.Profile = append(r.Profile, p)
+		if ref != "" {
+			r.profiles[ref] = len(r.Profile) - 1
+		}
+	}
+	r.indexedOf = r.Profile
+	r.PageNumber = o.PageNumber
+}
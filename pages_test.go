@@ -0,0 +1,82 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newPagingServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var page int
+		parts := strings.Split(r.URL.Path, "/")
+		for i := range parts {
+			if parts[i] == FilterPageNum && i+1 < len(parts) {
+				fmt.Sscan(parts[i+1], &page)
+			}
+		}
+		fmt.Fprintf(w, `{"Trip":{"id":"%d"},"Profile":{"@attributes":{"ref":"abc"}},"page_num":"%d","page_size":"1","max_page":"3"}`, page, page)
+	}))
+}
+
+func TestTrips(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		var requests int32
+		srv := newPagingServer(&requests)
+		c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+
+		var ids []string
+		for trip, err := range c.Trips(context.Background(), &TripListFilter{Past: true}, WithPageSize(1), WithPrefetch(prefetch)) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, trip.Id)
+		}
+		if strings.Join(ids, ",") != "1,2,3" {
+			t.Errorf("Unexpected trips with prefetch %v: %v", prefetch, ids)
+		}
+
+		resp, err := c.ListAll(context.Background(), new(TripListFilter), WithPrefetch(prefetch))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Trip) != 3 || len(resp.Profile) != 1 || resp.PageNumber.String() != "3" {
+			t.Errorf("Unexpected merged response: %d trips, %d profiles, page %s", len(resp.Trip), len(resp.Profile), resp.PageNumber)
+		}
+
+		atomic.StoreInt32(&requests, 0)
+		for range c.Trips(context.Background(), nil) {
+			break
+		}
+		if n := atomic.LoadInt32(&requests); n != 1 {
+			t.Errorf("Expected 1 request after stopping early, got %d", n)
+		}
+		srv.Close()
+	}
+}
+
+func TestTripsCanceled(t *testing.T) {
+	var requests int32
+	srv := newPagingServer(&requests)
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var n int
+	var lastErr error
+	for _, err := range c.Trips(ctx, nil) {
+		n++
+		lastErr = err
+		cancel()
+	}
+	if n != 2 || !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("Expected iteration to stop with context.Canceled, got %d items, %v", n, lastErr)
+	}
+}
@@ -0,0 +1,293 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrNoPoints is returned when a points value is empty.
+var ErrNoPoints = errors.New("tripit: no points value")
+
+// Points is a parsed points value, such as a balance or an activity amount.
+type Points struct {
+	Value float64 // numeric value; negative for deductions
+	Unit  string  // unit or currency as given by the program, for example "miles" or "$"; may be empty
+}
+
+// String returns the value and unit.
+func (p Points) String() string {
+	v := strconv.FormatFloat(p.Value, 'f', -1, 64)
+	switch {
+	case p.Unit == "":
+		return v
+	case len(p.Unit) <= 3 && !unicode.IsLetter([]rune(p.Unit)[0]):
+		return p.Unit + v
+	}
+	return v + " " + p.Unit
+}
+
+// ParsePoints parses a points value as shown by a program, such as "12,345 miles",
+// "12.345 Meilen", "1 234 pts" or "$1,500.50". Group separators may be commas, periods,
+// spaces or apostrophes. A single comma or period followed by exactly three digits is
+// treated as a group separator; otherwise the last comma or period is the decimal separator.
+// A minus sign makes the value negative only if it comes immediately before the digits,
+// as in "-2,000 miles"; in "Miles - 500" it is a separator.
+func ParsePoints(s string) (Points, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Points{}, ErrNoPoints
+	}
+	r := []rune(s)
+	i := 0
+	neg := false
+	var prefix string
+	for i < len(r) && !unicode.IsDigit(r[i]) {
+		switch {
+		case r[i] == '-' || r[i] == '\u2212':
+			neg = i+1 < len(r) && unicode.IsDigit(r[i+1])
+		case r[i] == '+' || unicode.IsSpace(r[i]):
+		default:
+			prefix += string(r[i])
+		}
+		i++
+	}
+	start := i
+	for i < len(r) && (unicode.IsDigit(r[i]) || isPointsSeparator(r[i]) && i+1 < len(r) && unicode.IsDigit(r[i+1])) {
+		i++
+	}
+	if start == i {
+		return Points{}, fmt.Errorf("tripit: invalid points value %q", s)
+	}
+	num := normalizePoints(r[start:i])
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return Points{}, fmt.Errorf("tripit: invalid points value %q", s)
+	}
+	if neg {
+		v = -v
+	}
+	unit := strings.TrimSpace(string(r[i:]))
+	if unit == "" {
+		unit = prefix
+	}
+	return Points{Value: v, Unit: unit}, nil
+}
+
+// isPointsSeparator returns true if c can separate digit groups or decimals.
+func isPointsSeparator(c rune) bool {
+	switch c {
+	case ',', '.', ' ', '\'', '\u00a0', '\u202f', '\u2019':
+		return true
+	}
+	return false
+}
+
+// normalizePoints removes group separators from the digits and separators in r, and
+// converts the decimal separator to a period.
+func normalizePoints(r []rune) string {
+	dec := -1
+	last := -1
+	count := map[rune]int{}
+	for i, c := range r {
+		if c == ',' || c == '.' {
+			count[c]++
+			last = i
+		}
+	}
+	if last >= 0 {
+		c := r[last]
+		other := ','
+		if c == ',' {
+			other = '.'
+		}
+		switch {
+		case count[other] > 0:
+			// Both separators are used, so the last one is the decimal separator.
+			dec = last
+		case count[c] == 1 && len(r)-last-1 != 3:
+			dec = last
+		}
+	}
+	var b strings.Builder
+	for i, c := range r {
+		switch {
+		case i == dec:
+			b.WriteByte('.')
+		case unicode.IsDigit(c):
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// BalancePoints returns the parsed Balance.
+func (pp *PointsProgram) BalancePoints() (Points, error) {
+	return ParsePoints(pp.Balance)
+}
+
+// EliteYtdQualifyPoints returns the parsed EliteYtdQualify.
+func (pp *PointsProgram) EliteYtdQualifyPoints() (Points, error) {
+	return ParsePoints(pp.EliteYtdQualify)
+}
+
+// EliteNeedToEarnPoints returns the parsed EliteNeedToEarn.
+func (pp *PointsProgram) EliteNeedToEarnPoints() (Points, error) {
+	return ParsePoints(pp.EliteNeedToEarn)
+}
+
+// BasePoints returns the parsed Base.
+func (pa *PointsProgramActivity) BasePoints() (Points, error) {
+	return ParsePoints(pa.Base)
+}
+
+// BonusPoints returns the parsed Bonus.
+func (pa *PointsProgramActivity) BonusPoints() (Points, error) {
+	return ParsePoints(pa.Bonus)
+}
+
+// TotalPoints returns the parsed Total. If Total is empty, the sum of Base and Bonus is returned.
+func (pa *PointsProgramActivity) TotalPoints() (Points, error) {
+	if strings.TrimSpace(pa.Total) != "" {
+		return ParsePoints(pa.Total)
+	}
+	base, err := pa.BasePoints()
+	if err != nil {
+		return Points{}, err
+	}
+	if bonus, err := pa.BonusPoints(); err == nil {
+		base.Value += bonus.Value
+	} else if !errors.Is(err, ErrNoPoints) {
+		return Points{}, err
+	}
+	return base, nil
+}
+
+// AmountPoints returns the parsed Amount.
+func (pe *PointsProgramExpiration) AmountPoints() (Points, error) {
+	return ParsePoints(pe.Amount)
+}
+
+// ExpiringPoints are points in a program that expire on a date.
+type ExpiringPoints struct {
+	Program *PointsProgram
+	Date    Date
+	Points  Points
+}
+
+// MonthlyPoints are the points earned in a program in a month.
+type MonthlyPoints struct {
+	Program *PointsProgram
+	Year    int
+	Month   time.Month
+	Points  Points // sum of the positive activity totals in the month
+}
+
+// EliteProgress is a program's progress towards the next elite status.
+type EliteProgress struct {
+	Program    *PointsProgram
+	Status     string  // current elite status
+	NextStatus string  // next elite status
+	Qualified  Points  // qualifying points earned this year
+	NeedToEarn Points  // qualifying points still needed
+	Percent    float64 // percentage of the qualifying points earned, between 0 and 100
+}
+
+// PointsReport summarizes points programs.
+type PointsReport struct {
+	Expiring []ExpiringPoints // expirations in the report period, by date
+	Earned   []MonthlyPoints  // points earned per program and month, by program and then month
+	Elite    []EliteProgress  // progress for programs with elite status information
+}
+
+// NewPointsReport builds a report for the given programs. Expiring lists the expirations
+// from today through the given number of days after today. Values that cannot be parsed
+// are left out of the report.
+func NewPointsReport(programs []PointsProgram, today Date, days int) *PointsReport {
+	report := new(PointsReport)
+	end := today.AddDays(days)
+	for i := range programs {
+		pp := &programs[i]
+		for j := range pp.Expiration {
+			e := &pp.Expiration[j]
+			if e.Date.IsZero() || e.Date.Before(today) || e.Date.After(end) {
+				continue
+			}
+			if p, err := e.AmountPoints(); err == nil {
+				report.Expiring = append(report.Expiring, ExpiringPoints{Program: pp, Date: e.Date, Points: p})
+			}
+		}
+		report.Earned = append(report.Earned, earnedByMonth(pp)...)
+		if ep, ok := eliteProgress(pp); ok {
+			report.Elite = append(report.Elite, ep)
+		}
+	}
+	sort.SliceStable(report.Expiring, func(i, j int) bool {
+		return report.Expiring[i].Date.Before(report.Expiring[j].Date)
+	})
+	return report
+}
+
+// earnedByMonth sums the program's positive activity totals by month. Redemptions and
+// other negative totals are not points earned, so they are left out.
+func earnedByMonth(pp *PointsProgram) []MonthlyPoints {
+	var result []MonthlyPoints
+	byMonth := make(map[Date]int)
+	for i := range pp.Activity {
+		a := &pp.Activity[i]
+		p, err := a.TotalPoints()
+		if a.Date.IsZero() || err != nil || p.Value <= 0 {
+			continue
+		}
+		month := Date{a.Date.Year, a.Date.Month, 1}
+		if k, ok := byMonth[month]; ok {
+			result[k].Points.Value += p.Value
+			continue
+		}
+		byMonth[month] = len(result)
+		result = append(result, MonthlyPoints{Program: pp, Year: month.Year, Month: month.Month, Points: p})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		return a.Year < b.Year || a.Year == b.Year && a.Month < b.Month
+	})
+	return result
+}
+
+// eliteProgress returns the program's progress to the next elite status. It returns false
+// if the program does not report both the qualifying points and the points needed.
+func eliteProgress(pp *PointsProgram) (EliteProgress, bool) {
+	q, err := pp.EliteYtdQualifyPoints()
+	if err != nil {
+		return EliteProgress{}, false
+	}
+	need, err := pp.EliteNeedToEarnPoints()
+	if err != nil {
+		return EliteProgress{}, false
+	}
+	ep := EliteProgress{Program: pp, Status: pp.EliteStatus, NextStatus: pp.EliteNextStatus, Qualified: q, NeedToEarn: need}
+	switch total := q.Value + need.Value; {
+	case need.Value <= 0:
+		ep.Percent = 100
+	case total > 0:
+		ep.Percent = q.Value / total * 100
+	}
+	return ep, true
+}
+
+// PointsReport lists the user's points programs and builds a report with the expirations
+// within the given number of days, using the current date in the local time zone.
+func (t *TripIt) PointsReport(ctx context.Context, days int) (*PointsReport, error) {
+	// Points program lists are not paged, and ListAll would send a page_num parameter that
+	// TripIt does not support for them.
+	resp, err := t.ListFiltered(ctx, &PointsProgramListFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return NewPointsReport(resp.PointsProgram, DateOf(time.Now()), days), nil
+}
@@ -0,0 +1,128 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParsePoints(t *testing.T) {
+	tests := []struct {
+		in    string
+		value float64
+		unit  string
+	}{
+		{"12,345 miles", 12345, "miles"},
+		{"12.345 Meilen", 12345, "Meilen"},
+		{"1 234 567 pts", 1234567, "pts"},
+		{"1 234 points", 1234, "points"},
+		{"1'234'567", 1234567, ""},
+		{"$1,500.50", 1500.5, "$"},
+		{"1.234,56 €", 1234.56, "€"},
+		{"12,5 EQS", 12.5, "EQS"},
+		{"-2,000 miles", -2000, "miles"},
+		{"\u22122,000 miles", -2000, "miles"},
+		{"Miles - 500", 500, "Miles"},
+		{"- 500", 500, ""},
+		{"500", 500, ""},
+		{"0", 0, ""},
+	}
+	for _, tt := range tests {
+		p, err := ParsePoints(tt.in)
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if p.Value != tt.value || p.Unit != tt.unit {
+			t.Errorf("%q: expected %v %q, got %v %q", tt.in, tt.value, tt.unit, p.Value, p.Unit)
+		}
+	}
+	if _, err := ParsePoints(" "); !errors.Is(err, ErrNoPoints) {
+		t.Errorf("Expected ErrNoPoints, got %v", err)
+	}
+	if _, err := ParsePoints("n/a"); err == nil {
+		t.Error("Expected error for n/a")
+	}
+	if s := (Points{12345, "miles"}).String(); s != "12345 miles" {
+		t.Errorf("Unexpected string %q", s)
+	}
+	if s := (Points{1500.5, "$"}).String(); s != "$1500.5" {
+		t.Errorf("Unexpected string %q", s)
+	}
+}
+
+func TestPointsReport(t *testing.T) {
+	programs := []PointsProgram{
+		{
+			Name:            "SkyMiles",
+			Balance:         "52,000 miles",
+			EliteStatus:     "Silver",
+			EliteNextStatus: "Gold",
+			EliteYtdQualify: "30,000 MQM",
+			EliteNeedToEarn: "20,000 MQM",
+			Activity: PointsProgramActivityVector{
+				{Date: Date{2024, time.January, 5}, Base: "1,000", Bonus: "500"},
+				{Date: Date{2024, time.January, 20}, Total: "2,000 miles"},
+				{Date: Date{2024, time.January, 25}, Total: "-300 miles"},
+				{Date: Date{2024, time.February, 1}, Total: "-5,000 miles"},
+				{Date: Date{2023, time.December, 31}, Total: "750 miles"},
+				{Date: Date{2024, time.March, 1}, Total: "unknown"},
+			},
+			Expiration: PointsProgramExpirationVector{
+				{Date: Date{2024, time.April, 30}, Amount: "3,000 miles"},
+				{Date: Date{2024, time.March, 10}, Amount: "1,000 miles"},
+				{Date: Date{2025, time.January, 1}, Amount: "9,000 miles"},
+				{Date: Date{2024, time.March, 1}, Amount: "100 miles"},
+			},
+		},
+		{Name: "Hotel Rewards", Balance: "8.000 Punkte"},
+	}
+	report := NewPointsReport(programs, Date{2024, time.March, 5}, 60)
+
+	if len(report.Expiring) != 2 || report.Expiring[0].Date != (Date{2024, time.March, 10}) || report.Expiring[1].Points.Value != 3000 {
+		t.Errorf("Unexpected expirations: %+v", report.Expiring)
+	}
+	expected := []struct {
+		month time.Month
+		value float64
+	}{{time.December, 750}, {time.January, 3500}} // redemptions are not earned
+	if len(report.Earned) != len(expected) {
+		t.Fatalf("Unexpected earned points: %+v", report.Earned)
+	}
+	for i, e := range expected {
+		if m := report.Earned[i]; m.Month != e.month || m.Points.Value != e.value || m.Program != &programs[0] {
+			t.Errorf("Expected %v %v, got %+v", e.month, e.value, m)
+		}
+	}
+	if len(report.Elite) != 1 || report.Elite[0].Percent != 60 || report.Elite[0].NextStatus != "Gold" {
+		t.Errorf("Unexpected elite progress: %+v", report.Elite)
+	}
+	if b, err := programs[1].BalancePoints(); err != nil || b.Value != 8000 {
+		t.Errorf("Unexpected balance: %v, %v", b, err)
+	}
+}
+
+func TestPointsReportRequest(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"PointsProgram":{"name":"SkyMiles","elite_next_status":"Gold","elite_ytd_qualify":"10","elite_need_to_earn":"30"},"max_page":"3"}`))
+	}))
+	defer srv.Close()
+	c := NewClient(&WebAuthCredential{"user@site.com", "password"}, WithApiUrl(srv.URL), WithHttpClient(srv.Client()))
+
+	report, err := c.PointsReport(context.Background(), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The list is not paged, so page_num is not sent and max_page is ignored.
+	if len(paths) != 1 || paths[0] != "/v1/list/points_program/format/json" {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+	if len(report.Elite) != 1 || report.Elite[0].Program.Name != "SkyMiles" || report.Elite[0].Percent != 25 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
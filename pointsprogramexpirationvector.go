@@ -1,31 +0,0 @@
-package tripit
-
-// This file was generated by a tool. Do not edit.
-
-import (
-	"encoding/json"
-)
-
-// PointsProgramExpirationVector is a specialization of Vector for PointsProgramExpiration objects.
-type PointsProgramExpirationVector []PointsProgramExpiration
-
-// UnmarshalJSON builds the vector from the JSON in b.
-func (p *PointsProgramExpirationVector) UnmarshalJSON(b []byte) error {
-	var arr *[]PointsProgramExpiration
-	arr = (*[]PointsProgramExpiration)(p)
-	*arr = nil
-	err := json.Unmarshal(b, arr)
-	if err != nil {
-		*arr = make([]PointsProgramExpiration, 1)
-		err := json.Unmarshal(b, &(*arr)[0])
-		if err != nil {
-			if err2, ok := err.(*json.UnmarshalTypeError); ok && err2.Value == "null" {
-				*arr = (*arr)[0:0]
-			} else {
-				return err
-			}
-		}
-		
-	}
-	return nil
-}
@@ -0,0 +1,105 @@
+package tripit
+
+import (
+	"sort"
+)
+
+// indexProfiles builds the index of the response's profiles by reference. It is called
+// when a response is decoded and when pages are merged, before the response is shared.
+func (r *Response) indexProfiles() {
+	r.profiles = make(map[string]int, len(r.Profile))
+	for i := range r.Profile {
+		if ref := r.Profile[i].Attributes.Ref; ref != "" {
+			if _, ok := r.profiles[ref]; !ok {
+				r.profiles[ref] = i
+			}
+		}
+	}
+	r.indexedOf = r.Profile
+}
+
+// indexCurrent returns true if the index was built for the current r.Profile, which has
+// not been replaced or appended to since.
+func (r *Response) indexCurrent() bool {
+	if r.profiles == nil || len(r.Profile) != len(r.indexedOf) {
+		return false
+	}
+	return len(r.Profile) == 0 || &r.Profile[0] == &r.indexedOf[0]
+}
+
+// ProfileByRef returns the profile in the response with the given reference, or nil if
+// the response does not contain it. Responses returned by the client are indexed by
+// reference; if r.Profile has been replaced or appended to since, the profiles are
+// searched instead. ProfileByRef does not modify the response, so it is safe to call
+// from several goroutines.
+func (r *Response) ProfileByRef(ref string) *Profile {
+	if ref == "" {
+		return nil
+	}
+	if r.indexCurrent() {
+		if i, ok := r.profiles[ref]; ok && r.Profile[i].Attributes.Ref == ref {
+			return &r.Profile[i]
+		}
+		return nil
+	}
+	for i := range r.Profile {
+		if r.Profile[i].Attributes.Ref == ref {
+			return &r.Profile[i]
+		}
+	}
+	return nil
+}
+
+// UnresolvedRefs returns the profile references used by invitees and closeness matches
+// in the response's trips that do not match a profile in the response. The references
+// are sorted and each one is listed once.
+func (r *Response) UnresolvedRefs() []string {
+	seen := make(map[string]bool)
+	var refs []string
+	check := func(ref string) {
+		if ref != "" && !seen[ref] && r.ProfileByRef(ref) == nil {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	for _, trip := range r.Trip {
+		if trip == nil {
+			continue
+		}
+		for _, inv := range trip.Invitees() {
+			check(inv.Attributes.ProfileRef)
+		}
+		for _, m := range trip.Matches() {
+			check(m.Attributes.ProfileRef)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// Profile returns the invitee's profile from the response, or nil if it is not in the response.
+func (i *Invitee) Profile(resp *Response) *Profile {
+	return resp.ProfileByRef(i.Attributes.ProfileRef)
+}
+
+// Profile returns the profile of the nearby user from the response, or nil if it is not
+// in the response.
+func (m *ClosenessMatch) Profile(resp *Response) *Profile {
+	return resp.ProfileByRef(m.Attributes.ProfileRef)
+}
+
+// Invitees returns the users invited to the trip.
+func (t *Trip) Invitees() []Invitee {
+	if t.TripInvitees == nil {
+		return nil
+	}
+	return t.TripInvitees.Invitee
+}
+
+// Matches returns the TripIt users who are near the trip.
+func (t *Trip) Matches() []ClosenessMatch {
+	if t.ClosenessMatches == nil {
+		return nil
+	}
+	return t.ClosenessMatches.ClosenessMatch
+}
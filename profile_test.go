@@ -0,0 +1,76 @@
+package tripit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestProfileLinks(t *testing.T) {
+	const data = `{"Trip":[` +
+		`{"id":"1","TripInvitees":{"Invitee":[{"@attributes":{"profile_ref":"abc"}},{"@attributes":{"profile_ref":"zzz"}}]},` +
+		`"ClosenessMatches":{"Match":{"@attributes":{"profile_ref":"def"}}}},` +
+		`{"id":"2","TripInvitees":{"Invitee":{"@attributes":{"profile_ref":"yyy"}}},"ClosenessMatches":{"Match":{"@attributes":{"profile_ref":"zzz"}}}}],` +
+		`"Profile":[{"@attributes":{"ref":"abc"},"screen_name":"friend"},{"@attributes":{"ref":"def"},"screen_name":"nearby"}]}`
+	var r Response
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		t.Fatal(err)
+	}
+	r.indexProfiles()
+
+	trip := r.Trip[0]
+	if n := len(trip.Invitees()); n != 2 {
+		t.Fatalf("Expected 2 invitees, got %d", n)
+	}
+	if p := trip.Invitees()[0].Profile(&r); p == nil || p.ScreenName != "friend" {
+		t.Errorf("Unexpected invitee profile: %+v", p)
+	}
+	if p := trip.Invitees()[1].Profile(&r); p != nil {
+		t.Errorf("Expected no profile, got %+v", p)
+	}
+	if p := trip.Matches()[0].Profile(&r); p == nil || p.ScreenName != "nearby" {
+		t.Errorf("Unexpected match profile: %+v", p)
+	}
+	if refs := r.UnresolvedRefs(); !reflect.DeepEqual(refs, []string{"yyy", "zzz"}) {
+		t.Errorf("Unexpected unresolved refs: %v", refs)
+	}
+	if (&Trip{}).Invitees() != nil || (&Trip{}).Matches() != nil {
+		t.Error("Expected no invitees or matches")
+	}
+
+	// Lookups only read the response, so they may run concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if p := r.ProfileByRef("def"); p == nil || p.ScreenName != "nearby" {
+				t.Errorf("Unexpected profile: %+v", p)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Profiles added by merging pages, or by changing the slice, are found too.
+	r.merge(&Response{Profile: ProfileVector{{Attributes: ProfileAttributes{Ref: "zzz"}, ScreenName: "late"}, {Attributes: ProfileAttributes{Ref: "abc"}, ScreenName: "duplicate"}}})
+	if !r.indexCurrent() || len(r.Profile) != 3 {
+		t.Errorf("Expected the merged profiles to be indexed, got %+v", r.Profile)
+	}
+	if p := r.ProfileByRef("zzz"); p == nil || p.ScreenName != "late" {
+		t.Errorf("Unexpected merged profile: %+v", p)
+	}
+	if p := r.ProfileByRef("abc"); p == nil || p.ScreenName != "friend" {
+		t.Errorf("Unexpected profile after merge: %+v", p)
+	}
+	if refs := r.UnresolvedRefs(); !reflect.DeepEqual(refs, []string{"yyy"}) {
+		t.Errorf("Unexpected unresolved refs: %v", refs)
+	}
+	r.Profile = ProfileVector{{Attributes: ProfileAttributes{Ref: "yyy"}, ScreenName: "new"}}
+	if r.indexCurrent() {
+		t.Error("Expected the index to be out of date")
+	}
+	if p := r.ProfileByRef("yyy"); p == nil || p.ScreenName != "new" || r.ProfileByRef("abc") != nil || r.ProfileByRef("") != nil {
+		t.Errorf("Unexpected profile after reassigning: %+v", p)
+	}
+}
@@ -0,0 +1,140 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request cannot be granted by the rate limiter
+// before the context's deadline.
+var ErrRateLimited = errors.New("tripit: rate limit wait would exceed context deadline")
+
+// RateLimiter is a token bucket rate limiter. TripIt throttles requests per consumer
+// key, so a single RateLimiter should be shared by all TripIt objects that use the
+// same consumer key. It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64   // tokens added per second
+	burst  float64   // maximum number of tokens
+	tokens float64   // available tokens, negative when requests are waiting
+	last   time.Time // time tokens was last updated
+	stats  RateLimiterStats
+
+	now   func() time.Time                                 // clock, replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error // waits for d, replaced in tests
+}
+
+// RateLimiterStats contains metrics about the time requests spent waiting.
+type RateLimiterStats struct {
+	Granted   int64         // number of requests allowed to proceed
+	Waited    int64         // number of granted requests that had to wait
+	Rejected  int64         // number of requests that failed because of the context
+	TotalWait time.Duration // total time granted requests spent waiting
+	MaxWait   time.Duration // longest time a granted request spent waiting
+}
+
+// NewRateLimiter creates a rate limiter that allows perSecond requests per second on
+// average, with bursts of up to burst requests.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{rate: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now(), now: time.Now, sleep: sleep}
+}
+
+var sharedLimiters = struct {
+	sync.Mutex
+	m map[string]*RateLimiter
+}{m: make(map[string]*RateLimiter)}
+
+// SharedRateLimiter returns the rate limiter registered for the given consumer key,
+// creating it with the given rate and burst if there is none yet. Use it to share one
+// limiter among all TripIt objects created for the same consumer key.
+func SharedRateLimiter(consumerKey string, perSecond float64, burst int) *RateLimiter {
+	sharedLimiters.Lock()
+	defer sharedLimiters.Unlock()
+	l, ok := sharedLimiters.m[consumerKey]
+	if !ok {
+		l = NewRateLimiter(perSecond, burst)
+		sharedLimiters.m[consumerKey] = l
+	}
+	return l
+}
+
+// SetRateLimiter sets the rate limiter used for requests. Each attempt, including
+// retries, takes one token. A nil limiter disables rate limiting, which is the default.
+func (t *TripIt) SetRateLimiter(l *RateLimiter) {
+	t.limiter = l
+}
+
+// advance adds the tokens accumulated since the last update. The lock must be held.
+func (l *RateLimiter) advance(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// Wait blocks until a request may proceed. If the context's deadline would expire
+// before then, Wait fails immediately with ErrRateLimited. If the context is canceled
+// while waiting, the context's error is returned.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := l.now()
+	l.mu.Lock()
+	l.advance(now)
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		if l.rate <= 0 {
+			wait = time.Duration(1<<63 - 1)
+		} else {
+			wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		l.tokens++
+		l.stats.Rejected++
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+	l.mu.Unlock()
+
+	if wait > 0 {
+		if err := l.sleep(ctx, wait); err != nil {
+			l.mu.Lock()
+			l.advance(l.now())
+			l.tokens++
+			if l.tokens > l.burst {
+				l.tokens = l.burst
+			}
+			l.stats.Rejected++
+			l.mu.Unlock()
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	l.stats.Granted++
+	if wait > 0 {
+		l.stats.Waited++
+		l.stats.TotalWait += wait
+		if wait > l.stats.MaxWait {
+			l.stats.MaxWait = wait
+		}
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// Stats returns a snapshot of the rate limiter's metrics.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,78 @@
+package tripit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock for a RateLimiter that only moves when the limiter sleeps.
+type fakeClock struct {
+	t     time.Time
+	slept []time.Duration
+}
+
+// install makes l use the fake clock.
+func (c *fakeClock) install(l *RateLimiter) {
+	c.t = time.Now()
+	l.last = c.t
+	l.now = func() time.Time { return c.t }
+	l.sleep = func(ctx context.Context, d time.Duration) error {
+		c.slept = append(c.slept, d)
+		c.t = c.t.Add(d)
+		return ctx.Err()
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	var clock fakeClock
+	l := NewRateLimiter(20, 2)
+	clock.install(l)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The burst is granted at once, then each request waits 1/20 s for a token.
+	if len(clock.slept) != 2 || clock.slept[0] != 50*time.Millisecond || clock.slept[1] != 50*time.Millisecond {
+		t.Errorf("Expected two waits of 50ms, got %v", clock.slept)
+	}
+	s := l.Stats()
+	if s.Granted != 4 || s.Waited != 2 || s.TotalWait != 100*time.Millisecond || s.MaxWait != 50*time.Millisecond {
+		t.Errorf("Unexpected stats: %+v", s)
+	}
+
+	// A wait that would outlast the deadline fails without waiting.
+	l = NewRateLimiter(0.01, 1)
+	clock = fakeClock{}
+	clock.install(l)
+	l.Wait(ctx)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, ErrRateLimited) || len(clock.slept) != 0 {
+		t.Errorf("Expected ErrRateLimited without waiting, got %v after %v", err, clock.slept)
+	}
+
+	// A canceled context fails at once.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if s := l.Stats(); s.Rejected != 1 {
+		t.Errorf("Expected 1 rejected request, got %d", s.Rejected)
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	a := SharedRateLimiter("key", 5, 5)
+	b := SharedRateLimiter("key", 10, 10)
+	if a != b {
+		t.Error("Expected the same rate limiter for the same consumer key")
+	}
+	if a == SharedRateLimiter("other", 5, 5) {
+		t.Error("Expected different rate limiters for different consumer keys")
+	}
+}
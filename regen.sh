@@ -1,31 +0,0 @@
-#!/bin/bash
-genvect/genvect -name Error
-genvect/genvect -name Warning
-genvect/genvect -name ClosenessMatch
-genvect/genvect -name Group
-genvect/genvect -name Invitee
-genvect/genvect -name ProfileEmailAddress
-genvect/genvect -name TripCrsRemark
-genvect/genvect -name TripPtr -type "*Trip"
-genvect/genvect -name ActivityObjectPtr -type "*ActivityObject"
-genvect/genvect -name AirObjectPtr -type "*AirObject"
-genvect/genvect -name CarObjectPtr -type "*CarObject"
-genvect/genvect -name CruiseObjectPtr -type "*CruiseObject"
-genvect/genvect -name DirectionsObjectPtr -type "*DirectionsObject"
-genvect/genvect -name LodgingObjectPtr -type "*LodgingObject"
-genvect/genvect -name MapObjectPtr -type "*MapObject"
-genvect/genvect -name NoteObjectPtr -type "*NoteObject"
-genvect/genvect -name RailObjectPtr -type "*RailObject"
-genvect/genvect -name RestaurantObjectPtr -type "*RestaurantObject"
-genvect/genvect -name TransportObjectPtr -type "*TransportObject"
-genvect/genvect -name WeatherObject
-genvect/genvect -name PointsProgram
-genvect/genvect -name Profile
-genvect/genvect -name ImagePtr -type "*Image"
-genvect/genvect -name TravelerPtr -type "*Traveler"
-genvect/genvect -name AirSegmentPtr -type "*AirSegment"
-genvect/genvect -name RailSegmentPtr -type "*RailSegment"
-genvect/genvect -name TransportSegmentPtr -type "*TransportSegment"
-genvect/genvect -name CruiseSegmentPtr -type "*CruiseSegment"
-genvect/genvect -name PointsProgramActivity
-genvect/genvect -name PointsProgramExpiration
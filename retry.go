@@ -0,0 +1,151 @@
+package tripit
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a transient error are retried.
+// Requests are retried on network errors and on HTTP 429, 500, 502, 503 and 504
+// responses. By default only idempotent calls (Get and List) are retried.
+type RetryPolicy struct {
+	MaxAttempts        int           // total number of attempts, including the first; values below 2 disable retries
+	InitialBackoff     time.Duration // delay before the first retry
+	MaxBackoff         time.Duration // upper bound on the delay between attempts; 0 means no limit
+	Multiplier         float64       // factor applied to the delay after each retry; values below 1 are treated as 1
+	Jitter             float64       // fraction of the delay that is randomized, between 0 and 1
+	RetryNonIdempotent bool          // also retry Create, Replace, Delete and GetAccessToken
+}
+
+// DefaultRetryPolicy is a reasonable retry policy for batch jobs.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// SetRetryPolicy sets the retry policy used for requests. A nil policy disables retries,
+// which is the default.
+func (t *TripIt) SetRetryPolicy(p *RetryPolicy) {
+	t.retryPolicy = p
+}
+
+// retryableStatus returns true if the HTTP status code indicates a transient failure.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry, where retry 1 is the first retry.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	m := p.Multiplier
+	if m < 1 {
+		m = 1
+	}
+	d := float64(p.InitialBackoff) * math.Pow(m, float64(retry-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		j := p.Jitter
+		if j > 1 {
+			j = 1
+		}
+		d = d * (1 - j + 2*j*rand.Float64())
+	}
+	return time.Duration(d)
+}
+
+// retryAfter parses the Retry-After header, which holds either a number of seconds
+// or an HTTP date. It returns false if the header is missing or invalid.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if tm, err := http.ParseTime(v); err == nil {
+		d := time.Until(tm)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// send builds and sends the request, retrying according to the retry policy. Before each
+// attempt it waits for the rate limiter and builds a new http.Request, including a freshly
+// signed authorization header. The caller must close the body of the returned response.
+func (t *TripIt) send(ctx context.Context, r *apiRequest) (*http.Response, error) {
+	attempts := 1
+	if p := t.retryPolicy; p != nil && p.MaxAttempts > 1 && (r.idempotent || p.RetryNonIdempotent) {
+		attempts = p.MaxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		req, err := t.newRequest(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.do(req)
+		if attempt >= attempts {
+			return resp, err
+		}
+		var delay time.Duration
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			delay = t.retryPolicy.backoff(attempt)
+		} else if retryableStatus(resp.StatusCode) {
+			var ok bool
+			if delay, ok = retryAfter(resp); !ok {
+				delay = t.retryPolicy.backoff(attempt)
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			return resp, nil
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
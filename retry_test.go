@@ -0,0 +1,60 @@
+package tripit
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		if len(auth) < 3 || strings.Contains(r.URL.Path, "/create/") {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"timestamp":"1306543281"}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, ApiVersion, srv.Client(), NewOAuth3LeggedCredential("key", "secret", "token", "tokensecret"))
+	c.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour})
+	_, err := c.Get(ObjectTypeTrip, 1)
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if len(auth) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(auth))
+	}
+	if auth[0] == auth[1] || auth[1] == auth[2] {
+		t.Error("Authorization header was reused between attempts")
+	}
+
+	auth = nil
+	_, err = c.Create(&Request{Trip: &Trip{DisplayName: "Test"}})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 APIError, got %v", err)
+	}
+	if len(auth) != 1 {
+		t.Errorf("Expected Create not to be retried, got %d attempts", len(auth))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d := p.backoff(2)
+		if d < time.Second || d > 3*time.Second {
+			t.Fatalf("Backoff out of range: %v", d)
+		}
+	}
+	if d := p.backoff(10); d > 7500*time.Millisecond {
+		t.Errorf("Backoff exceeds maximum with jitter: %v", d)
+	}
+}
@@ -1,10 +1,11 @@
 package main
 
 import (
-	"github.com/ancientlore/go-tripit"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"github.com/ancientlore/go-tripit"
 	"log"
 	"net/http"
 	"net/url"
@@ -71,6 +72,37 @@ func getSession(w http.ResponseWriter, req *http.Request) map[string]string {
 	return m
 }
 
+// newClient creates a TripIt client for the given credential.
+func newClient(cred tripit.Authorizable) *tripit.TripIt {
+	return tripit.NewClient(cred, tripit.WithApiUrl(*url_), tripit.WithResponseErrors(true))
+}
+
+// showError renders err, using the API error page when TripIt returned Error or Warning entries.
+func showError(w http.ResponseWriter, err error) {
+	var apiErr *tripit.APIError
+	if errors.As(err, &apiErr) && (len(apiErr.Errors) > 0 || len(apiErr.Warnings) > 0) {
+		m := make(map[string]interface{})
+		m["Error"] = apiErr.Errors
+		m["Warning"] = apiErr.Warnings
+		apierrorT.Execute(w, m)
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+	errorT.Execute(w, err)
+}
+
+// showWarnings renders the API error page if a successful response contains Warning entries,
+// and returns true if it did.
+func showWarnings(w http.ResponseWriter, resp *tripit.Response) bool {
+	if len(resp.Warning) == 0 {
+		return false
+	}
+	m := make(map[string]interface{})
+	m["Warning"] = resp.Warning
+	apierrorT.Execute(w, m)
+	return true
+}
+
 func startSessionManager() {
 	session = make(chan getsess, 8)
 	go sessionManager()
@@ -108,8 +140,7 @@ func Auth(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuthRequestCredential(*oauthConsumerKey, *oauthConsumerSecret)
 	log.Print("Cred ", cred)
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
+	t := newClient(cred)
 	m, err := t.GetRequestToken()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -126,8 +157,7 @@ func Auth(w http.ResponseWriter, req *http.Request) {
 func CheckAuth(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuth3LeggedCredential(*oauthConsumerKey, *oauthConsumerSecret, sess["oauth_token"], sess["oauth_token_secret"])
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
+	t := newClient(cred)
 	m, err := t.GetAccessToken()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -143,26 +173,17 @@ func CheckAuth(w http.ResponseWriter, req *http.Request) {
 func Trips(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuth3LeggedCredential(*oauthConsumerKey, *oauthConsumerSecret, sess["oauth_token"], sess["oauth_token_secret"])
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
-	resp, err := t.List(tripit.ObjectTypeTrip, map[string]string{tripit.FilterTraveler: "true", tripit.FilterPast: "true"})
+	t := newClient(cred)
+	resp, err := t.ListFiltered(req.Context(), &tripit.TripListFilter{Traveler: tripit.TravelerTrue, Past: true})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorT.Execute(w, err)
+		showError(w, err)
 		return
 	}
-	m := make(map[string]interface{})
-	m["Result"] = resp
-	if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-		if resp.Warning != nil {
-			m["Warning"] = resp.Warning
-		}
-		if resp.Error != nil {
-			m["Error"] = resp.Error
-		}
-		apierrorT.Execute(w, m)
+	if showWarnings(w, resp) {
 		return
 	}
+	m := make(map[string]interface{})
+	m["Result"] = resp
 	m["Trip"] = resp.Trip
 	b, _ := json.MarshalIndent(resp, "", "\t")
 	m["JSON"] = string(b)
@@ -172,8 +193,7 @@ func Trips(w http.ResponseWriter, req *http.Request) {
 func Details(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuth3LeggedCredential(*oauthConsumerKey, *oauthConsumerSecret, sess["oauth_token"], sess["oauth_token_secret"])
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
+	t := newClient(cred)
 	q, err := url.ParseQuery(req.URL.RawQuery)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -189,22 +209,14 @@ func Details(w http.ResponseWriter, req *http.Request) {
 	}
 	resp, err := t.Get(objType, uint(objId))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorT.Execute(w, err)
+		showError(w, err)
 		return
 	}
-	m := make(map[string]interface{})
-	m["Result"] = resp
-	if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-		if resp.Warning != nil {
-			m["Warning"] = resp.Warning
-		}
-		if resp.Error != nil {
-			m["Error"] = resp.Error
-		}
-		apierrorT.Execute(w, m)
+	if showWarnings(w, resp) {
 		return
 	}
+	m := make(map[string]interface{})
+	m["Result"] = resp
 	b, _ := json.MarshalIndent(resp, "", "\t")
 	m["JSON"] = string(b)
 	detailsT.Execute(w, m)
@@ -213,8 +225,7 @@ func Details(w http.ResponseWriter, req *http.Request) {
 func List(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuth3LeggedCredential(*oauthConsumerKey, *oauthConsumerSecret, sess["oauth_token"], sess["oauth_token_secret"])
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
+	t := newClient(cred)
 	q, err := url.ParseQuery(req.URL.RawQuery)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -231,22 +242,14 @@ func List(w http.ResponseWriter, req *http.Request) {
 
 	resp, err := t.List(objType, filters)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorT.Execute(w, err)
+		showError(w, err)
 		return
 	}
-	m := make(map[string]interface{})
-	m["Result"] = resp
-	if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-		if resp.Warning != nil {
-			m["Warning"] = resp.Warning
-		}
-		if resp.Error != nil {
-			m["Error"] = resp.Error
-		}
-		apierrorT.Execute(w, m)
+	if showWarnings(w, resp) {
 		return
 	}
+	m := make(map[string]interface{})
+	m["Result"] = resp
 	b, _ := json.MarshalIndent(resp, "", "\t")
 	m["JSON"] = string(b)
 	detailsT.Execute(w, m)
@@ -255,15 +258,13 @@ func List(w http.ResponseWriter, req *http.Request) {
 func Edit(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuth3LeggedCredential(*oauthConsumerKey, *oauthConsumerSecret, sess["oauth_token"], sess["oauth_token_secret"])
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
+	t := newClient(cred)
 	q, err := url.ParseQuery(req.URL.RawQuery)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		errorT.Execute(w, err)
 		return
 	}
-	objType := q["t"][0]
 	var objId uint64 = 0
 	tmp, ok := q["id"]
 	if ok {
@@ -277,24 +278,11 @@ func Edit(w http.ResponseWriter, req *http.Request) {
 	var trip *tripit.Trip
 	m := make(map[string]interface{})
 	if objId > 0 {
-		resp, err := t.Get(objType, uint(objId))
+		trip, err = t.GetTrip(req.Context(), uint(objId))
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			errorT.Execute(w, err)
-			return
-		}
-		if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-			if resp.Warning != nil {
-				m["Warning"] = resp.Warning
-			}
-			if resp.Error != nil {
-				m["Error"] = resp.Error
-			}
-			apierrorT.Execute(w, m)
+			showError(w, err)
 			return
 		}
-		m["Result"] = resp
-		trip = (resp.Trip)[0]
 	} else {
 		trip = new(tripit.Trip)
 	}
@@ -305,8 +293,7 @@ func Edit(w http.ResponseWriter, req *http.Request) {
 func Save(w http.ResponseWriter, req *http.Request) {
 	sess := getSession(w, req)
 	cred := tripit.NewOAuth3LeggedCredential(*oauthConsumerKey, *oauthConsumerSecret, sess["oauth_token"], sess["oauth_token_secret"])
-	var client http.Client
-	t := tripit.New(*url_, tripit.ApiVersion, &client, cred)
+	t := newClient(cred)
 	err := req.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -326,43 +313,23 @@ func Save(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 	var trip tripit.Trip
-	m := make(map[string]interface{})
 	if objId > 0 {
-		resp, err := t.Get(objType, uint(objId))
+		p, err := t.GetTrip(req.Context(), uint(objId))
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			errorT.Execute(w, err)
-			return
-		}
-		if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-			if resp.Warning != nil {
-				m["Warning"] = resp.Warning
-			}
-			if resp.Error != nil {
-				m["Error"] = resp.Error
-			}
-			apierrorT.Execute(w, m)
+			showError(w, err)
 			return
 		}
-		trip = *(resp.Trip)[0]
+		trip = *p
 		trip.DisplayName = req.Form["DisplayName"][0]
 		trip.Description = req.Form["Description"][0]
 		request := new(tripit.Request)
 		request.Trip = &trip
-		resp, err = t.Replace(objType, uint(objId), request)
+		resp, err := t.Replace(objType, uint(objId), request)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			errorT.Execute(w, err)
+			showError(w, err)
 			return
 		}
-		if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-			if resp.Warning != nil {
-				m["Warning"] = resp.Warning
-			}
-			if resp.Error != nil {
-				m["Error"] = resp.Error
-			}
-			apierrorT.Execute(w, m)
+		if showWarnings(w, resp) {
 			return
 		}
 	} else {
@@ -374,18 +341,10 @@ func Save(w http.ResponseWriter, req *http.Request) {
 		log.Print(string(b))
 		resp, err := t.Create(request)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			errorT.Execute(w, err)
+			showError(w, err)
 			return
 		}
-		if (resp.Warning != nil && len(resp.Warning) > 0) || (resp.Error != nil && len(resp.Error) > 0) {
-			if resp.Warning != nil {
-				m["Warning"] = resp.Warning
-			}
-			if resp.Error != nil {
-				m["Error"] = resp.Error
-			}
-			apierrorT.Execute(w, m)
+		if showWarnings(w, resp) {
 			return
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -30,28 +31,28 @@ const (
 
 // Request contains the objects that can be sent to TripIt in a request.
 type Request struct {
-	Invitation       []Invitation      `json:"Invitation,omitempty"`       // optional
-	Trip             *Trip             `json:"Trip,omitempty"`             // optional
-	ActivityObject   *ActivityObject   `json:"ActivityObject,omitempty"`   // optional
-	AirObject        *AirObject        `json:"AirObject,omitempty"`        // optional
-	CarObject        *CarObject        `json:"CarObject,omitempty"`        // optional
-	CruiseObject     *CruiseObject     `json:"CruiseObject,omitempty"`     // optional
-	DirectionsObject *DirectionsObject `json:"DirectionsObject,omitempty"` // optional
-	LodgingObject    *LodgingObject    `json:"LodgingObject,omitempty"`    // optional
-	MapObject        *MapObject        `json:"MapObject,omitempty"`        // optional
-	NoteObject       *NoteObject       `json:"NoteObject,omitempty"`       // optional
-	RailObject       *RailObject       `json:"RailObject,omitempty"`       // optional
-	RestaurantObject *RestaurantObject `json:"RestaurantObject,omitempty"` // optional
-	TransportObject  *TransportObject  `json:"TransportObject,omitempty"`  // optional
+	Invitation       []Invitation      `json:"Invitation,omitempty" xml:"Invitation,omitempty"`             // optional
+	Trip             *Trip             `json:"Trip,omitempty" xml:"Trip,omitempty"`                         // optional
+	ActivityObject   *ActivityObject   `json:"ActivityObject,omitempty" xml:"ActivityObject,omitempty"`     // optional
+	AirObject        *AirObject        `json:"AirObject,omitempty" xml:"AirObject,omitempty"`               // optional
+	CarObject        *CarObject        `json:"CarObject,omitempty" xml:"CarObject,omitempty"`               // optional
+	CruiseObject     *CruiseObject     `json:"CruiseObject,omitempty" xml:"CruiseObject,omitempty"`         // optional
+	DirectionsObject *DirectionsObject `json:"DirectionsObject,omitempty" xml:"DirectionsObject,omitempty"` // optional
+	LodgingObject    *LodgingObject    `json:"LodgingObject,omitempty" xml:"LodgingObject,omitempty"`       // optional
+	MapObject        *MapObject        `json:"MapObject,omitempty" xml:"MapObject,omitempty"`               // optional
+	NoteObject       *NoteObject       `json:"NoteObject,omitempty" xml:"NoteObject,omitempty"`             // optional
+	RailObject       *RailObject       `json:"RailObject,omitempty" xml:"RailObject,omitempty"`             // optional
+	RestaurantObject *RestaurantObject `json:"RestaurantObject,omitempty" xml:"RestaurantObject,omitempty"` // optional
+	TransportObject  *TransportObject  `json:"TransportObject,omitempty" xml:"TransportObject,omitempty"`   // optional
 }
 
 // Error is returned from TripIt on error conditions.
 type Error struct {
-	Code              int     `json:"code,string,omitempty" xml:"code"`                               // read-only
-	DetailedErrorCode float64 `json:"detailed_error_code,string,omitempty" xml:"detailed_error_code"` // optional, read-only
-	Description       string  `json:"description,omitempty" xml:"description"`                        // read-only
-	EntityType        string  `json:"entity_type,omitempty" xml:"entity_type"`                        // read-only
-	Timestamp         string  `json:"timestamp,omitempty" xml:"timestamp"`                            // read-only, xs:datetime
+	Code              int     `json:"code,string,omitempty" xml:"code,omitempty"`                               // read-only
+	DetailedErrorCode float64 `json:"detailed_error_code,string,omitempty" xml:"detailed_error_code,omitempty"` // optional, read-only
+	Description       string  `json:"description,omitempty" xml:"description,omitempty"`                        // read-only
+	EntityType        string  `json:"entity_type,omitempty" xml:"entity_type,omitempty"`                        // read-only
+	Timestamp         string  `json:"timestamp,omitempty" xml:"timestamp,omitempty"`                            // read-only, xs:datetime
 }
 
 // Time returns a time.Time object for the Timestamp.
@@ -71,9 +72,9 @@ func (e *Error) Error() string {
 
 // Warning is returned from TripIt to indicate warning conditions
 type Warning struct {
-	Description string `json:"description,omitempty"` // read-only
-	EntityType  string `json:"entity_type,omitempty"` // read-only
-	Timestamp   string `json:"timestamp,omitempty"`   // read-only, xs:datetime
+	Description string `json:"description,omitempty" xml:"description,omitempty"` // read-only
+	EntityType  string `json:"entity_type,omitempty" xml:"entity_type,omitempty"` // read-only
+	Timestamp   string `json:"timestamp,omitempty" xml:"timestamp,omitempty"`     // read-only, xs:datetime
 }
 
 // Time returns a time.Time object for the Timestamp.
@@ -95,31 +96,32 @@ func (w *Warning) Error() string {
 
 // Response represents a TripIt API Response
 type Response struct {
-	Timestamp        string                    `json:"timestamp,omitempty" xml:"timestamp"`
-	NumBytes         int                       `json:"num_bytes,string,omitempty" xml:"num_bytes"`
-	Error            ErrorVector               `json:"Error,omitempty" xml:"Error"`                       // optional
-	Warning          WarningVector             `json:"Warning,omitempty" xml:"Warning"`                   // optional
-	Trip             TripPtrVector             `json:"Trip,omitempty" xml:"Trip"`                         // optional
-	ActivityObject   ActivityObjectPtrVector   `json:"ActivityObject,omitempty" xml:"ActivityObject"`     // optional
-	AirObject        AirObjectPtrVector        `json:"AirObject,omitempty" xml:"AirObject"`               // optional
-	CarObject        CarObjectPtrVector        `json:"CarObject,omitempty" xml:"CarObject"`               // optional
-	CruiseObject     CruiseObjectPtrVector     `json:"CruiseObject,omitempty" xml:"CruiseObject"`         // optional
-	DirectionsObject DirectionsObjectPtrVector `json:"DirectionsObject,omitempty" xml:"DirectionsObject"` // optional
-	LodgingObject    LodgingObjectPtrVector    `json:"LodgingObject,omitempty" xml:"LodgingObject"`       // optional
-	MapObject        MapObjectPtrVector        `json:"MapObject,omitempty" xml:"MapObject"`               // optional
-	NoteObject       NoteObjectPtrVector       `json:"NoteObject,omitempty" xml:"NoteObject"`             // optional
-	RailObject       RailObjectPtrVector       `json:"RailObject,omitempty" xml:"RailObject"`             // optional
-	RestaurantObject RestaurantObjectPtrVector `json:"RestaurantObject,omitempty" xml:"RestaurantObject"` // optional
-	TransportObject  TransportObjectPtrVector  `json:"TransportObject,omitempty" xml:"TransportObject"`   // optional
-	WeatherObject    WeatherObjectVector       `json:"WeatherObject,omitempty" xml:"WeatherObject"`       // optional
-	PointsProgram    PointsProgramVector       `json:"PointsProgram,omitempty" xml:"PointsProgram"`       // optional
-	Profile          ProfileVector             `json:"Profile,omitempty" xml:"Profile"`                   // optional
-
-	PageNumber json.Number `json:"page_num,omitempty" xml:"page_num"`   // when pagination is activated
-	PageSize   json.Number `json:"page_size,omitempty" xml:"page_size"` // when pagination is activated
-	MaxPage    json.Number `json:"max_page,omitempty" xml:"max_page"`   // when pagination is activated
-
-	// @TODO need to add invitee stuff
+	Timestamp        string                    `json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	NumBytes         int                       `json:"num_bytes,string,omitempty" xml:"num_bytes,omitempty"`
+	Error            ErrorVector               `json:"Error,omitempty" xml:"Error,omitempty"`                       // optional
+	Warning          WarningVector             `json:"Warning,omitempty" xml:"Warning,omitempty"`                   // optional
+	Trip             TripPtrVector             `json:"Trip,omitempty" xml:"Trip,omitempty"`                         // optional
+	ActivityObject   ActivityObjectPtrVector   `json:"ActivityObject,omitempty" xml:"ActivityObject,omitempty"`     // optional
+	AirObject        AirObjectPtrVector        `json:"AirObject,omitempty" xml:"AirObject,omitempty"`               // optional
+	CarObject        CarObjectPtrVector        `json:"CarObject,omitempty" xml:"CarObject,omitempty"`               // optional
+	CruiseObject     CruiseObjectPtrVector     `json:"CruiseObject,omitempty" xml:"CruiseObject,omitempty"`         // optional
+	DirectionsObject DirectionsObjectPtrVector `json:"DirectionsObject,omitempty" xml:"DirectionsObject,omitempty"` // optional
+	LodgingObject    LodgingObjectPtrVector    `json:"LodgingObject,omitempty" xml:"LodgingObject,omitempty"`       // optional
+	MapObject        MapObjectPtrVector        `json:"MapObject,omitempty" xml:"MapObject,omitempty"`               // optional
+	NoteObject       NoteObjectPtrVector       `json:"NoteObject,omitempty" xml:"NoteObject,omitempty"`             // optional
+	RailObject       RailObjectPtrVector       `json:"RailObject,omitempty" xml:"RailObject,omitempty"`             // optional
+	RestaurantObject RestaurantObjectPtrVector `json:"RestaurantObject,omitempty" xml:"RestaurantObject,omitempty"` // optional
+	TransportObject  TransportObjectPtrVector  `json:"TransportObject,omitempty" xml:"TransportObject,omitempty"`   // optional
+	WeatherObject    WeatherObjectVector       `json:"WeatherObject,omitempty" xml:"WeatherObject,omitempty"`       // optional
+	PointsProgram    PointsProgramVector       `json:"PointsProgram,omitempty" xml:"PointsProgram,omitempty"`       // optional
+	Profile          ProfileVector             `json:"Profile,omitempty" xml:"Profile,omitempty"`                   // optional
+
+	PageNumber json.Number `json:"page_num,omitempty" xml:"page_num,omitempty"`   // when pagination is activated
+	PageSize   json.Number `json:"page_size,omitempty" xml:"page_size,omitempty"` // when pagination is activated
+	MaxPage    json.Number `json:"max_page,omitempty" xml:"max_page,omitempty"`   // when pagination is activated
+
+	profiles  map[string]int // index in Profile by reference, see ProfileByRef
+	indexedOf ProfileVector  // Profile when profiles was built
 }
 
 // Time returns a time.Time object for the Timestamp
@@ -138,27 +140,27 @@ func (r *Response) Time() (time.Time, error) {
 // Multi-line address will be ignored if single-line address is present.
 // See documentation for more information.
 type Address struct {
-	Address   string  `json:"address,omitempty" xml:"address"`            // optional
-	Addr1     string  `json:"addr1,omitempty" xml:"addr1"`                // optional
-	Addr2     string  `json:"addr2,omitempty" xml:"addr2"`                // optional
-	City      string  `json:"city,omitempty" xml:"city"`                  // optional
-	State     string  `json:"state,omitempty" xml:"state"`                // optional
-	Zip       string  `json:"zip,omitempty" xml:"zip"`                    // optional
-	Country   string  `json:"country,omitempty" xml:"country"`            // optional
-	Latitude  float64 `json:"latitude,string,omitempty" xml:"latitude"`   // optional, read-only
-	Longitude float64 `json:"longitude,string,omitempty" xml:"longitude"` // optional, read-only
+	Address   string  `json:"address,omitempty" xml:"address,omitempty"`            // optional
+	Addr1     string  `json:"addr1,omitempty" xml:"addr1,omitempty"`                // optional
+	Addr2     string  `json:"addr2,omitempty" xml:"addr2,omitempty"`                // optional
+	City      string  `json:"city,omitempty" xml:"city,omitempty"`                  // optional
+	State     string  `json:"state,omitempty" xml:"state,omitempty"`                // optional
+	Zip       string  `json:"zip,omitempty" xml:"zip,omitempty"`                    // optional
+	Country   string  `json:"country,omitempty" xml:"country,omitempty"`            // optional
+	Latitude  float64 `json:"latitude,string,omitempty" xml:"latitude,omitempty"`   // optional, read-only
+	Longitude float64 `json:"longitude,string,omitempty" xml:"longitude,omitempty"` // optional, read-only
 }
 
 // Traveler contains information about a traveler.
 type Traveler struct {
-	FirstName                string `json:"first_name,omitempty" xml:"first_name"`                                 // optional
-	MiddleName               string `json:"middle_name,omitempty" xml:"middle_name"`                               // optional
-	LastName                 string `json:"last_name,omitempty" xml:"last_name"`                                   // optional
-	FrequentTravelerNum      string `json:"frequent_traveler_num,omitempty" xml:"frequent_traveler_num"`           // optional
-	FrequentTravelerSupplier string `json:"frequent_traveler_supplier,omitempty" xml:"frequent_traveler_supplier"` // optional
-	MealPreference           string `json:"meal_preference,omitempty" xml:"meal_preference"`                       // optional
-	SeatPreference           string `json:"seat_preference,omitempty" xml:"seat_preference"`                       // optional
-	TicketNum                string `json:"ticket_num,omitempty" xml:"ticket_num"`                                 //optional
+	FirstName                string `json:"first_name,omitempty" xml:"first_name,omitempty"`                                 // optional
+	MiddleName               string `json:"middle_name,omitempty" xml:"middle_name,omitempty"`                               // optional
+	LastName                 string `json:"last_name,omitempty" xml:"last_name,omitempty"`                                   // optional
+	FrequentTravelerNum      string `json:"frequent_traveler_num,omitempty" xml:"frequent_traveler_num,omitempty"`           // optional
+	FrequentTravelerSupplier string `json:"frequent_traveler_supplier,omitempty" xml:"frequent_traveler_supplier,omitempty"` // optional
+	MealPreference           string `json:"meal_preference,omitempty" xml:"meal_preference,omitempty"`                       // optional
+	SeatPreference           string `json:"seat_preference,omitempty" xml:"seat_preference,omitempty"`                       // optional
+	TicketNum                string `json:"ticket_num,omitempty" xml:"ticket_num,omitempty"`                                 //optional
 }
 
 // Flight status values
@@ -178,20 +180,20 @@ const (
 
 // FlightStatus fields are read-only and only available for monitored TripIt Pro AirSegments.
 type FlightStatus struct {
-	ScheduledDepartureDateTime *DateTime `json:"ScheduledDepartureDateTime,omitempty" xml:"ScheduledDepartureDateTime"` // optional, read-only
-	EstimatedDepartureDateTime *DateTime `json:"EstimatedDepartureDateTime,omitempty" xml:"EstimatedDepartureDateTime"` // optional, read-only
-	ScheduledArrivalDateTime   *DateTime `json:"ScheduledArrivalDateTime,omitempty" xml:"ScheduledArrivalDateTime"`     // optional, read-only
-	EstimatedArrivalDateTime   *DateTime `json:"EstimatedArrivalDateTime,omitempty" xml:"EstimatedArrivalDateTime"`     // optional, read-only
-	FlightStatus               int       `json:"flight_status,string,omitempty" xml:"flight_status"`                    // optional, read-only
-	IsConnectionAtRisk         bool      `json:"is_connection_at_risk,string,omitempty" xml:"is_connection_at_risk"`    // optional, read-only
-	DepartureTerminal          string    `json:"departure_terminal,omitempty" xml:"departure_terminal"`                 // optional, read-only
-	DepartureGate              string    `json:"departure_gate,omitempty" xml:"departure_gate"`                         // optional, read-only
-	ArrivalTerminal            string    `json:"arrival_terminal,omitempty" xml:"arrival_terminal"`                     // optional, read-only
-	ArrivalGate                string    `json:"arrival_gate,omitempty" xml:"arrival_gate"`                             // optional, read-only
-	LayoverMinutes             string    `json:"layover_minutes,omitempty" xml:"layover_minutes"`                       // optional, read-only
-	BaggageClaim               string    `json:"baggage_claim,omitempty" xml:"baggage_claim"`                           // optional, read-only
-	DivertedAirportCode        string    `json:"diverted_airport_code,omitempty" xml:"diverted_airport_code"`           // optional, read-only
-	LastModified               string    `json:"last_modified,omitempty" xml:"last_modified"`                           // read-only
+	ScheduledDepartureDateTime *DateTime `json:"ScheduledDepartureDateTime,omitempty" xml:"ScheduledDepartureDateTime,omitempty"` // optional, read-only
+	EstimatedDepartureDateTime *DateTime `json:"EstimatedDepartureDateTime,omitempty" xml:"EstimatedDepartureDateTime,omitempty"` // optional, read-only
+	ScheduledArrivalDateTime   *DateTime `json:"ScheduledArrivalDateTime,omitempty" xml:"ScheduledArrivalDateTime,omitempty"`     // optional, read-only
+	EstimatedArrivalDateTime   *DateTime `json:"EstimatedArrivalDateTime,omitempty" xml:"EstimatedArrivalDateTime,omitempty"`     // optional, read-only
+	FlightStatus               int       `json:"flight_status,string,omitempty" xml:"flight_status,omitempty"`                    // optional, read-only
+	IsConnectionAtRisk         bool      `json:"is_connection_at_risk,string,omitempty" xml:"is_connection_at_risk,omitempty"`    // optional, read-only
+	DepartureTerminal          string    `json:"departure_terminal,omitempty" xml:"departure_terminal,omitempty"`                 // optional, read-only
+	DepartureGate              string    `json:"departure_gate,omitempty" xml:"departure_gate,omitempty"`                         // optional, read-only
+	ArrivalTerminal            string    `json:"arrival_terminal,omitempty" xml:"arrival_terminal,omitempty"`                     // optional, read-only
+	ArrivalGate                string    `json:"arrival_gate,omitempty" xml:"arrival_gate,omitempty"`                             // optional, read-only
+	LayoverMinutes             string    `json:"layover_minutes,omitempty" xml:"layover_minutes,omitempty"`                       // optional, read-only
+	BaggageClaim               string    `json:"baggage_claim,omitempty" xml:"baggage_claim,omitempty"`                           // optional, read-only
+	DivertedAirportCode        string    `json:"diverted_airport_code,omitempty" xml:"diverted_airport_code,omitempty"`           // optional, read-only
+	LastModified               string    `json:"last_modified,omitempty" xml:"last_modified,omitempty"`                           // read-only
 }
 
 // LastModifiedTime returns a time.Time object for LastModified.
@@ -205,58 +207,136 @@ func (fs *FlightStatus) LastModifiedTime() (time.Time, error) {
 
 // Image stores information about images.
 type Image struct {
-	Caption string `json:"caption,omitempty" xml:"caption"` // optional
+	Caption string `json:"caption,omitempty" xml:"caption,omitempty"` // optional
 	Url     string `json:"url" xml:"url"`
 }
 
 // DateTime Stores date and time zone information, for example:
-// {
-//	 "date":"2009-11-10",
-//   "time":"14:00:00",
-//    "timezone":"America\/Los_Angeles",
-//    "utc_offset":"-08:00"
-// }
+//
+//	{
+//		"date":"2009-11-10",
+//		"time":"14:00:00",
+//		"timezone":"America\/Los_Angeles",
+//		"utc_offset":"-08:00"
+//	}
 type DateTime struct {
-	Date      string `json:"date,omitempty" xml:"date"`             // optional, xs:date
-	Time      string `json:"time,omitempty" xml:"time"`             // optional, xs:time
-	Timezone  string `json:"timezone,omitempty" xml:"timezone"`     // optional, read-only
-	UtcOffset string `json:"utc_offset,omitempty" xml:"utc_offset"` // optional, read-only
+	Date      string `json:"date,omitempty" xml:"date,omitempty"`             // optional, xs:date
+	Time      string `json:"time,omitempty" xml:"time,omitempty"`             // optional, xs:time
+	Timezone  string `json:"timezone,omitempty" xml:"timezone,omitempty"`     // optional, read-only
+	UtcOffset string `json:"utc_offset,omitempty" xml:"utc_offset,omitempty"` // optional, read-only
 }
 
-// GetTime converts the time to a time.Time.
+// locations caches time zones loaded by name.
+var locations sync.Map
+
+// loadLocation loads the IANA time zone with the given name, caching the result.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// parseUtcOffset parses an offset like "-08:00" and returns it in seconds.
+func parseUtcOffset(s string) (int, error) {
+	t, err := time.Parse("-07:00", s)
+	if err != nil {
+		return 0, err
+	}
+	_, offset := t.Zone()
+	return offset, nil
+}
+
+// Location returns the time zone of the event. The IANA time zone in Timezone is used if
+// it can be loaded; otherwise a fixed zone for UtcOffset is used, or UTC if both are empty.
+// Build with the tripit_tzdata tag to embed the time zone database in programs that run
+// on systems without one.
+func (dt DateTime) Location() (*time.Location, error) {
+	if dt.Timezone != "" {
+		loc, err := loadLocation(dt.Timezone)
+		if err == nil {
+			return loc, nil
+		}
+		if dt.UtcOffset == "" {
+			return nil, err
+		}
+	}
+	if dt.UtcOffset != "" {
+		offset, err := parseUtcOffset(dt.UtcOffset)
+		if err != nil {
+			return nil, err
+		}
+		return time.FixedZone(dt.UtcOffset, offset), nil
+	}
+	return time.UTC, nil
+}
+
+// GetTime converts the time to a time.Time in the event's local time zone. See Location.
+// If Time is empty, the time is midnight. When a local time occurs twice, as it does when
+// daylight saving time ends, UtcOffset selects which of the two instants is meant.
 func (dt DateTime) GetTime() (time.Time, error) {
-	if dt.UtcOffset == "" {
-		return time.Parse(time.RFC3339, fmt.Sprintf("%sT%sZ", dt.Date, dt.Time))
+	loc, err := dt.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	tm := dt.Time
+	if tm == "" {
+		tm = "00:00:00"
 	}
-	return time.Parse(time.RFC3339, fmt.Sprintf("%sT%s%s", dt.Date, dt.Time, dt.UtcOffset))
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", dt.Date+"T"+tm, loc)
+	if err != nil || dt.UtcOffset == "" {
+		return t, err
+	}
+	offset, err := parseUtcOffset(dt.UtcOffset)
+	_, zoneOffset := t.Zone()
+	if err != nil || zoneOffset == offset {
+		return t, nil
+	}
+	// Use the instant with the given offset if the zone has that offset at that instant.
+	alt := t.Add(time.Duration(zoneOffset-offset) * time.Second)
+	if _, o := alt.Zone(); o == offset {
+		return alt, nil
+	}
+	return t, nil
 }
 
-// SetTime sets the values of the DateTime strucure from a time.Time.
+// SetTime sets the values of the DateTime strucure from a time.Time. Timezone is set
+// to the name of the time's location if it is an IANA time zone, and cleared otherwise.
 func (dt *DateTime) SetTime(t time.Time) {
 	dt.Date = t.Format("2006-01-02")
 	dt.Time = t.Format("15:04:05")
 	dt.UtcOffset = t.Format("-07:00")
-	dt.Timezone = t.Format("MST")
+	dt.Timezone = ""
+	if name := t.Location().String(); name != "Local" {
+		if _, err := loadLocation(name); err == nil {
+			dt.Timezone = name
+		}
+	}
 }
 
 // PointsProgram contains information about tracked travel programs for TripIt Pro users.
 // All PointsProgram elements are read-only.
 type PointsProgram struct {
-	Id                  uint                          `json:"id,string,omitempty" xml:"id"`                                       // read-only
-	Name                string                        `json:"name,omitempty" xml:"name"`                                          // optional, read-only
-	AccountNumber       string                        `json:"account_number,omitempty" xml:"account_number"`                      // optional, read-only
-	AccountLogin        string                        `json:"account_login,omitempty" xml:"account_login"`                        // optional, read-only
-	Balance             string                        `json:"balance,omitempty" xml:"balance"`                                    // optional, read-only
-	EliteStatus         string                        `json:"elite_status,omitempty" xml:"elite_status"`                          // optional, read-only
-	EliteNextStatus     string                        `json:"elite_next_status,omitempty" xml:"elite_next_status"`                // optional, read-only
-	EliteYtdQualify     string                        `json:"elite_ytd_qualify,omitempty" xml:"elite_ytd_qualify"`                // optional, read-only
-	EliteNeedToEarn     string                        `json:"elite_need_to_earn,omitempty" xml:"elite_need_to_earn"`              // optional, read-only
-	LastModified        string                        `json:"last_modified,omitempty" xml:"last_modified"`                        // read-only
-	TotalNumActivities  int                           `json:"total_num_activities,string,omitempty" xml:"total_num_activities"`   // read-only
-	TotalNumExpirations int                           `json:"total_num_expirations,string,omitempty" xml:"total_num_expirations"` // read-only
-	ErrorMessage        string                        `json:"error_message,omitempty" xml:"error_message"`                        // optional, read-only
-	Activity            PointsProgramActivityVector   `json:"Activity,omitempty" xml:"Activity"`                                  // optional, read-only
-	Expiration          PointsProgramExpirationVector `json:"Expiration,omitempty" xml:"Expiration"`                              // optional, read-only
+	Id                  uint                          `json:"id,string,omitempty" xml:"id,omitempty"`                                       // read-only
+	Name                string                        `json:"name,omitempty" xml:"name,omitempty"`                                          // optional, read-only
+	AccountNumber       string                        `json:"account_number,omitempty" xml:"account_number,omitempty"`                      // optional, read-only
+	AccountLogin        string                        `json:"account_login,omitempty" xml:"account_login,omitempty"`                        // optional, read-only
+	Balance             string                        `json:"balance,omitempty" xml:"balance,omitempty"`                                    // optional, read-only
+	EliteStatus         string                        `json:"elite_status,omitempty" xml:"elite_status,omitempty"`                          // optional, read-only
+	EliteNextStatus     string                        `json:"elite_next_status,omitempty" xml:"elite_next_status,omitempty"`                // optional, read-only
+	EliteYtdQualify     string                        `json:"elite_ytd_qualify,omitempty" xml:"elite_ytd_qualify,omitempty"`                // optional, read-only
+	EliteNeedToEarn     string                        `json:"elite_need_to_earn,omitempty" xml:"elite_need_to_earn,omitempty"`              // optional, read-only
+	LastModified        string                        `json:"last_modified,omitempty" xml:"last_modified,omitempty"`                        // read-only
+	TotalNumActivities  int                           `json:"total_num_activities,string,omitempty" xml:"total_num_activities,omitempty"`   // read-only
+	TotalNumExpirations int                           `json:"total_num_expirations,string,omitempty" xml:"total_num_expirations,omitempty"` // read-only
+	ErrorMessage        string                        `json:"error_message,omitempty" xml:"error_message,omitempty"`                        // optional, read-only
+	Activity            PointsProgramActivityVector   `json:"Activity,omitempty" xml:"Activity,omitempty"`                                  // optional, read-only
+	Expiration          PointsProgramExpirationVector `json:"Expiration,omitempty" xml:"Expiration,omitempty"`                              // optional, read-only
 }
 
 // LastModifiedTime returns a time.Time object for LastModified.
@@ -271,37 +351,35 @@ func (pp *PointsProgram) LastModifiedTime() (time.Time, error) {
 // PointsProgramActivity contains program transactions
 // All PointsProgramActivity elements are read-only
 type PointsProgramActivity struct {
-	Date        string `json:"date,omitempty" xml:"date"`               // read-only, xs:date
-	Description string `json:"description,omitempty" xml:"description"` // optional, read-only
-	Base        string `json:"base,omitempty" xml:"base"`               // optional, read-only
-	Bonus       string `json:"bonus,omitempty" xml:"bonus"`             // optional, read-only
-	Total       string `json:"total,omitempty" xml:"total"`             // optional, read-only
+	Date        Date   `json:"date,omitzero" xml:"date"`                          // read-only, xs:date
+	Description string `json:"description,omitempty" xml:"description,omitempty"` // optional, read-only
+	Base        string `json:"base,omitempty" xml:"base,omitempty"`               // optional, read-only
+	Bonus       string `json:"bonus,omitempty" xml:"bonus,omitempty"`             // optional, read-only
+	Total       string `json:"total,omitempty" xml:"total,omitempty"`             // optional, read-only
 }
 
-// Time returns a time.Time object for Date.
-// Note: This won't have proper time zone information
+// Time returns a time.Time object for Date, at midnight UTC.
 func (pa *PointsProgramActivity) Time() (time.Time, error) {
-	return time.Parse("2006-01-02", pa.Date)
+	return pa.Date.utcTime()
 }
 
 // PointsProgramExpiration elements are read-only.
 type PointsProgramExpiration struct {
-	Date   string `json:"date,omitempty" xml:"date"`     // read-only, xs:date
-	Amount string `json:"amount,omitempty" xml:"amount"` // optional, read-only
+	Date   Date   `json:"date,omitzero" xml:"date"`                // read-only, xs:date
+	Amount string `json:"amount,omitempty" xml:"amount,omitempty"` // optional, read-only
 }
 
-// Time returns a time.Time object for Date.
-// Note: This won't have proper time zone information.
+// Time returns a time.Time object for Date, at midnight UTC.
 func (pe *PointsProgramExpiration) Time() (time.Time, error) {
-	return time.Parse("2006-01-02", pe.Date)
+	return pe.Date.utcTime()
 }
 
 // TripShare contains information about which users a trip is shared with.
 type TripShare struct {
-	TripId            uint `json:"trip_id,string,omitempty" xml:"trip_id"`
-	IsTraveler        bool `json:"is_traveler,string,omitempty" xml:"is_traveler"`
-	IsReadOnly        bool `json:"is_read_only,string,omitempty" xml:"is_read_only"`
-	IsSentWithDetails bool `json:"is_sent_with_details,string,omitempty" xml:"is_sent_with_details"`
+	TripId            uint `json:"trip_id,string,omitempty" xml:"trip_id,omitempty"`
+	IsTraveler        bool `json:"is_traveler,string,omitempty" xml:"is_traveler,omitempty"`
+	IsReadOnly        bool `json:"is_read_only,string,omitempty" xml:"is_read_only,omitempty"`
+	IsSentWithDetails bool `json:"is_sent_with_details,string,omitempty" xml:"is_sent_with_details,omitempty"`
 }
 
 // ConnectionRequest stores connection request data.
@@ -310,69 +388,69 @@ type ConnectionRequest struct {
 
 // Invitation contains a list of users invited to see the trip.
 type Invitation struct {
-	EmailAddresses    []string           `json:"EmailAddresses,omitempty" xml:"EmailAddresses"`
-	TripShare         *TripShare         `json:"TripShare,omitempty" xml:"TripShare"`                 // optional
-	ConnectionRequest *ConnectionRequest `json:"ConnectionRequest,omitempty" xml:"ConnectionRequest"` // optional
-	Message           string             `json:"message,omitempty" xml:"message"`                     // optional
+	EmailAddresses    []string           `json:"EmailAddresses,omitempty" xml:"EmailAddresses>address,omitempty"` // sent as an EmailAddresses element with an address for each
+	TripShare         *TripShare         `json:"TripShare,omitempty" xml:"TripShare,omitempty"`                   // optional
+	ConnectionRequest *ConnectionRequest `json:"ConnectionRequest,omitempty" xml:"ConnectionRequest,omitempty"`   // optional
+	Message           string             `json:"message,omitempty" xml:"message,omitempty"`                       // optional
 }
 
 // Profile contains user information.
 // All Profile elements are read-only.
 type Profile struct {
-	Attributes            ProfileAttributes      `json:"_attributes" xml:"attributes"`                                // read-only
-	ProfileEmailAddresses *ProfileEmailAddresses `json:"ProfileEmailAddresses,omitempty" xml:"ProfileEmailAddresses"` // optional, read-only
-	GroupMemberships      *GroupMemberships      `json:"GroupMemberships,omitempty" xml:"GroupMemberships"`           // optional, read-only
-	IsClient              bool                   `json:"is_client,string,omitempty" xml:"is_client"`                  // read-only
-	IsPro                 bool                   `json:"is_pro,string,omitempty" xml:"is_pro"`                        // read-only
-	ScreenName            string                 `json:"screen_name,omitempty" xml:"screen_name"`                     // read-only
-	PublicDisplayName     string                 `json:"public_display_name,omitempty" xml:"public_display_name"`     // read-only
-	ProfileUrl            string                 `json:"profile_url,omitempty" xml:"profile_url"`                     // read-only
-	HomeCity              string                 `json:"home_city,omitempty" xml:"home_city"`                         // optional, read-only
-	Company               string                 `json:"company,omitempty" xml:"company"`                             // optional, read-only
-	AboutMeInfo           string                 `json:"about_me_info,omitempty" xml:"about_me_info"`                 // optional, read-only
-	PhotoUrl              string                 `json:"photo_url,omitempty" xml:"photo_url"`                         // optional, read-only
-	ActivityFeedUrl       string                 `json:"activity_feed_url,omitempty" xml:"activity_feed_url"`         // optional, read-only
-	AlertsFeedUrl         string                 `json:"alerts_feed_url,omitempty" xml:"alerts_feed_url"`             // optional, read-only
-	IcalUrl               string                 `json:"ical_url,omitempty" xml:"ical_url"`                           // optional, read-only
+	Attributes            ProfileAttributes      `json:"@attributes" xml:"ref,attr"`                                            // read-only
+	ProfileEmailAddresses *ProfileEmailAddresses `json:"ProfileEmailAddresses,omitempty" xml:"ProfileEmailAddresses,omitempty"` // optional, read-only
+	GroupMemberships      *GroupMemberships      `json:"GroupMemberships,omitempty" xml:"GroupMemberships,omitempty"`           // optional, read-only
+	IsClient              bool                   `json:"is_client,string,omitempty" xml:"is_client,omitempty"`                  // read-only
+	IsPro                 bool                   `json:"is_pro,string,omitempty" xml:"is_pro,omitempty"`                        // read-only
+	ScreenName            string                 `json:"screen_name,omitempty" xml:"screen_name,omitempty"`                     // read-only
+	PublicDisplayName     string                 `json:"public_display_name,omitempty" xml:"public_display_name,omitempty"`     // read-only
+	ProfileUrl            string                 `json:"profile_url,omitempty" xml:"profile_url,omitempty"`                     // read-only
+	HomeCity              string                 `json:"home_city,omitempty" xml:"home_city,omitempty"`                         // optional, read-only
+	Company               string                 `json:"company,omitempty" xml:"company,omitempty"`                             // optional, read-only
+	AboutMeInfo           string                 `json:"about_me_info,omitempty" xml:"about_me_info,omitempty"`                 // optional, read-only
+	PhotoUrl              string                 `json:"photo_url,omitempty" xml:"photo_url,omitempty"`                         // optional, read-only
+	ActivityFeedUrl       string                 `json:"activity_feed_url,omitempty" xml:"activity_feed_url,omitempty"`         // optional, read-only
+	AlertsFeedUrl         string                 `json:"alerts_feed_url,omitempty" xml:"alerts_feed_url,omitempty"`             // optional, read-only
+	IcalUrl               string                 `json:"ical_url,omitempty" xml:"ical_url,omitempty"`                           // optional, read-only
 }
 
 // ProfileEmailAddresses contains the list of email addresses for a user.
 type ProfileEmailAddresses struct {
-	ProfileEmailAddress ProfileEmailAddressVector `json:"ProfileEmailAddress,omitempty" xml:"ProfileEmailAddress"`
+	ProfileEmailAddress ProfileEmailAddressVector `json:"ProfileEmailAddress,omitempty" xml:"ProfileEmailAddress,omitempty"`
 }
 
 // GroupMemberships contains a list of groups that the user is a member of.
 type GroupMemberships struct {
-	Group GroupVector `json:"Group,omitempty" xml:"Group"` // optional, read-only
+	Group GroupVector `json:"Group,omitempty" xml:"Group,omitempty"` // optional, read-only
 }
 
 // ProfileAttributes represent links to profiles.
 type ProfileAttributes struct {
-	Ref string `json:"ref,omitempty" xml:"ref"` // read-only
+	Ref string `json:"ref,omitempty" xml:"ref,omitempty"` // read-only
 }
 
 // ProfileEmailAddress contains an email address and its properties.
 // All ProfileEmailAddress elements are read-only.
 type ProfileEmailAddress struct {
-	Address      string `json:"address" xml:"address"`                                // read-only
-	IsAutoImport bool   `json:"is_auto_import,string,omitempty" xml:"is_auto_import"` // read-only
-	IsConfirmed  bool   `json:"is_confirmed,string,omitempty" xml:"is_confirmed"`     // read-only
-	IsPrimary    bool   `json:"is_primary,string,omitempty" xml:"is_primary"`         // read-only
+	Address      string `json:"address" xml:"address"`                                          // read-only
+	IsAutoImport bool   `json:"is_auto_import,string,omitempty" xml:"is_auto_import,omitempty"` // read-only
+	IsConfirmed  bool   `json:"is_confirmed,string,omitempty" xml:"is_confirmed,omitempty"`     // read-only
+	IsPrimary    bool   `json:"is_primary,string,omitempty" xml:"is_primary,omitempty"`         // read-only
 }
 
 // Group contains data about a group in TripIt.
 // All Group elements are read-only.
 type Group struct {
-	DisplayName string `json:"display_name,omitempty" xml:"display_name"` // read-only
-	Url         string `json:"url" xml:"url"`                             // read-only
+	DisplayName string `json:"display_name,omitempty" xml:"display_name,omitempty"` // read-only
+	Url         string `json:"url" xml:"url"`                                       // read-only
 }
 
 // Invitee stores attributes about invitees to a trip.
 // All Invitee elements are read-only.
 type Invitee struct {
-	IsReadOnly bool              `json:"is_read_only,string,omitempty" xml:"is_read_only"` // read-only
-	IsTraveler bool              `json:"is_traveler,string,omitempty" xml:"is_traveler"`   // read-only
-	Attributes InviteeAttributes `json:"_attributes" xml:"attributes"`                     // read-only, Use the profile_ref attribute to reference a Profile
+	IsReadOnly bool              `json:"is_read_only,string,omitempty" xml:"is_read_only,omitempty"` // read-only
+	IsTraveler bool              `json:"is_traveler,string,omitempty" xml:"is_traveler,omitempty"`   // read-only
+	Attributes InviteeAttributes `json:"@attributes" xml:"profile_ref,attr"`                         // read-only, Use the profile_ref attribute to reference a Profile
 }
 
 // InviteeAttributes are used to link to user profiles.
@@ -383,14 +461,14 @@ type InviteeAttributes struct {
 // TripCrsRemark is a reservation system remark.
 // All TripCrsRemark elements are read-only.
 type TripCrsRemark struct {
-	RecordLocator string `json:"record_locator,omitempty" xml:"record_locator"` // read-only
-	Notes         string `json:"notes,omitempty" xml:"notes"`                   // read-only
+	RecordLocator string `json:"record_locator,omitempty" xml:"record_locator,omitempty"` // read-only
+	Notes         string `json:"notes,omitempty" xml:"notes,omitempty"`                   // read-only
 }
 
 // ClosenessMatch refers to nearby users.
 // All ClosenessMatch elements are read-only.
 type ClosenessMatch struct {
-	Attributes ClosenessMatchAttributes `json:"_attributes" xml:"attributes"` // read-only, Use the profile_ref attribute to reference a Profile
+	Attributes ClosenessMatchAttributes `json:"@attributes" xml:"profile_ref,attr"` // read-only, Use the profile_ref attribute to reference a Profile
 }
 
 // ClosenessMatchAttributes links to profiles of nearby users.
@@ -400,124 +478,121 @@ type ClosenessMatchAttributes struct {
 
 // Trip represents a trip in the TripIt model.
 type Trip struct {
-	ClosenessMatches       *ClosenessMatches `json:"ClosenessMatches,omitempty" xml:"ClosenessMatches"`                 // optional, ClosenessMatches are read-only
-	TripInvitees           *TripInvitees     `json:"TripInvitees,omitempty" xml:"TripInvitees"`                         // optional, TripInvitees are read-only
-	TripCrsRemarks         *TripCrsRemarks   `json:"TripCrsRemarks,omitempty" xml:"TripCrsRemarks"`                     // optional, TripCrsRemarks are read-only
-	Id                     string            `json:"id,omitempty" xml:"id"`                                             // optional, id is a read-only field
-	RelativeUrl            string            `json:"relative_url,omitempty" xml:"relative_url"`                         // optional, relative_url is a read-only field
-	StartDate              string            `json:"start_date,omitempty" xml:"start_date"`                             // optional, xs:date
-	EndDate                string            `json:"end_date,omitempty" xml:"end_date"`                                 // optional, xs:date
-	Description            string            `json:"description,omitempty" xml:"description"`                           // optional
-	DisplayName            string            `json:"display_name,omitempty" xml:"display_name"`                         // optional
-	ImageUrl               string            `json:"image_url,omitempty" xml:"image_url"`                               // optional
-	IsPrivate              bool              `json:"is_private,string,omitempty" xml:"is_private"`                      // optional
-	PrimaryLocation        string            `json:"primary_location,omitempty" xml:"primary_location"`                 // optional
-	PrimaryLocationAddress *Address          `json:"primary_location_address,omitempty" xml:"primary_location_address"` // optional, PrimaryLocationAddress is a read-only field
+	ClosenessMatches       *ClosenessMatches `json:"ClosenessMatches,omitempty" xml:"ClosenessMatches,omitempty"`                 // optional, ClosenessMatches are read-only
+	TripInvitees           *TripInvitees     `json:"TripInvitees,omitempty" xml:"TripInvitees,omitempty"`                         // optional, TripInvitees are read-only
+	TripCrsRemarks         *TripCrsRemarks   `json:"TripCrsRemarks,omitempty" xml:"TripCrsRemarks,omitempty"`                     // optional, TripCrsRemarks are read-only except with CrsLoad
+	Id                     string            `json:"id,omitempty" xml:"id,omitempty"`                                             // optional, id is a read-only field
+	RelativeUrl            string            `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                         // optional, relative_url is a read-only field
+	StartDate              Date              `json:"start_date,omitzero" xml:"start_date"`                                        // optional, xs:date
+	EndDate                Date              `json:"end_date,omitzero" xml:"end_date"`                                            // optional, xs:date
+	Description            string            `json:"description,omitempty" xml:"description,omitempty"`                           // optional
+	DisplayName            string            `json:"display_name,omitempty" xml:"display_name,omitempty"`                         // optional
+	ImageUrl               string            `json:"image_url,omitempty" xml:"image_url,omitempty"`                               // optional
+	IsPrivate              bool              `json:"is_private,string,omitempty" xml:"is_private,omitempty"`                      // optional
+	PrimaryLocation        string            `json:"primary_location,omitempty" xml:"primary_location,omitempty"`                 // optional
+	PrimaryLocationAddress *Address          `json:"primary_location_address,omitempty" xml:"primary_location_address,omitempty"` // optional, PrimaryLocationAddress is a read-only field
 }
 
 // TripInvitees are people invited to view a trip.
 type TripInvitees struct {
-	Invitee InviteeVector `json:"Invitee,omitempty" xml:"Invitee"` // optional, TripInvitees are read-only
+	Invitee InviteeVector `json:"Invitee,omitempty" xml:"Invitee,omitempty"` // optional, TripInvitees are read-only
 }
 
 // ClosenessMatches are TripIt users who are near this trip.
 type ClosenessMatches struct {
-	ClosenessMatch ClosenessMatchVector `json:"Match,omitempty" xml:"Match"` // optional, ClosenessMatches are read-only
+	ClosenessMatch ClosenessMatchVector `json:"Match,omitempty" xml:"Match,omitempty"` // optional, ClosenessMatches are read-only
 }
 
 // TripCrsRemarks are remarks from a reservation system.
 type TripCrsRemarks struct {
-	TripCrsRemark TripCrsRemarkVector `json:"TripCrsRemark,omitempty" xml:"TripCrsRemark"` // optional, TripCrsRemarks are read-only
+	TripCrsRemark TripCrsRemarkVector `json:"TripCrsRemark,omitempty" xml:"TripCrsRemark,omitempty"` // optional, TripCrsRemarks are read-only
 }
 
-// StartTime returns a time.Time object for StartDate.
-// Note: This won't have proper time zone information.
+// StartTime returns a time.Time object for StartDate, at midnight UTC.
 func (t *Trip) StartTime() (time.Time, error) {
-	return time.Parse("2006-01-02", t.StartDate)
+	return t.StartDate.utcTime()
 }
 
-// EndTime returns a time.Time object for EndDate.
-// Note: This won't have proper time zone information.
+// EndTime returns a time.Time object for EndDate, at midnight UTC.
 func (t *Trip) EndTime() (time.Time, error) {
-	return time.Parse("2006-01-02", t.EndDate)
+	return t.EndDate.utcTime()
 }
 
 // AirObject contains data about a flight.
 type AirObject struct {
-	Id                   string              `json:"id,omitempty" xml:"id"`                                         // optional, read-only
-	TripId               string              `json:"trip_id,omitempty" xml:"trip_id"`                               // optional
-	IsClientTraveler     bool                `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler"`  // optional, read-only
-	RelativeUrl          string              `json:"relative_url,omitempty" xml:"relative_url"`                     // optional, read-only
-	DisplayName          string              `json:"display_name,omitempty" xml:"display_name"`                     // optional
-	Image                ImagePtrVector      `json:"Image,omitempty" xml:"Image"`                                   // optional
-	CancellationDateTime *DateTime           `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime"`     // optional
-	BookingDate          string              `json:"booking_date,omitempty" xml:"booking_date"`                     // optional, xs:date
-	BookingRate          string              `json:"booking_rate,omitempty" xml:"booking_rate"`                     // optional
-	BookingSiteConfNum   string              `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num"`   // optional
-	BookingSiteName      string              `json:"booking_site_name,omitempty" xml:"booking_site_name"`           // optional
-	BookingSitePhone     string              `json:"booking_site_phone,omitempty" xml:"booking_site_phone"`         // optional
-	BookingSiteUrl       string              `json:"booking_site_url,omitempty" xml:"booking_site_url"`             // optional
-	RecordLocator        string              `json:"record_locator,omitempty" xml:"record_locator"`                 // optional
-	SupplierConfNum      string              `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num"`           // optional
-	SupplierContact      string              `json:"supplier_contact,omitempty" xml:"supplier_contact"`             // optional
-	SupplierEmailAddress string              `json:"supplier_email_address,omitempty" xml:"supplier_email_address"` // optional
-	SupplierName         string              `json:"supplier_name,omitempty" xml:"supplier_name"`                   // optional
-	SupplierPhone        string              `json:"supplier_phone,omitempty" xml:"supplier_phone"`                 // optional
-	SupplierUrl          string              `json:"supplier_url,omitempty" xml:"supplier_url"`                     // optional
-	IsPurchased          bool                `json:"is_purchased,string,omitempty" xml:"is_purchased"`              // optional
-	Notes                string              `json:"notes,omitempty" xml:"notes"`                                   // optional
-	Restrictions         string              `json:"restrictions,omitempty" xml:"restrictions"`                     // optional
-	TotalCost            string              `json:"total_cost,omitempty" xml:"total_cost"`                         // optional
-	Segment              AirSegmentPtrVector `json:"Segment,omitempty" xml:"Segment"`
-	Traveler             TravelerPtrVector   `json:"Traveler,omitempty" xml:"Traveler"` // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string              `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string              `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool                `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string              `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string              `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector      `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime           `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date                `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string              `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string              `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string              `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string              `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string              `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string              `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string              `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string              `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string              `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string              `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string              `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string              `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool                `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string              `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string              `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string              `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	Segment              AirSegmentPtrVector `json:"Segment,omitempty" xml:"Segment,omitempty"`
+	Traveler             TravelerPtrVector   `json:"Traveler,omitempty" xml:"Traveler,omitempty"` // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *AirObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // AirSegment contains details about individual flights.
 type AirSegment struct {
-	Status                *FlightStatus `json:"Status,omitempty" xml:"Status"`                                          // optional
-	StartDateTime         *DateTime     `json:"StartDateTime,omitempty" xml:"StartDateTime"`                            // optional
-	EndDateTime           *DateTime     `json:"EndDateTime,omitempty" xml:"EndDateTime"`                                // optional
-	StartAirportCode      string        `json:"start_airport_code,omitempty" xml:"start_airport_code"`                  // optional
-	StartAirportLatitude  float64       `json:"start_airport_latitude,string,omitempty" xml:"start_airport_latitude"`   // optional, read-only
-	StartAirportLongitude float64       `json:"start_airport_longitude,string,omitempty" xml:"start_airport_longitude"` // optional, read-only
-	StartCityName         string        `json:"start_city_name,omitempty" xml:"start_city_name"`                        // optional
-	StartGate             string        `json:"start_gate,omitempty" xml:"start_gate"`                                  // optional
-	StartTerminal         string        `json:"start_terminal,omitempty" xml:"start_terminal"`                          // optional
-	EndAirportCode        string        `json:"end_airport_code,omitempty" xml:"end_airport_code"`                      // optional
-	EndAirportLatitude    float64       `json:"end_airport_latitude,string,omitempty" xml:"end_airport_latitude"`       // optional, read-only
-	EndAirportLongitude   float64       `json:"end_airport_longitude,string,omitempty" xml:"end_airport_longitude"`     // optional, read-only
-	EndCityName           string        `json:"end_city_name,omitempty" xml:"end_city_name"`                            // optional
-	EndGate               string        `json:"end_gate,omitempty" xml:"end_gate"`                                      // optional
-	EndTerminal           string        `json:"end_terminal,omitempty" xml:"end_terminal"`                              // optional
-	MarketingAirline      string        `json:"marketing_airline,omitempty" xml:"marketing_airline"`                    // optional
-	MarketingAirlineCode  string        `json:"marketing_airline_code,omitempty" xml:"marketing_airline_code"`          // optional, read-only
-	MarketingFlightNumber string        `json:"marketing_flight_number,omitempty" xml:"marketing_flight_number"`        // optional
-	OperatingAirline      string        `json:"operating_airline,omitempty" xml:"operating_airline"`                    // optional
-	OperatingAirlineCode  string        `json:"operating_airline_code,omitempty" xml:"operating_airline_code"`          // optional, read-only
-	OperatingFlightNumber string        `json:"operating_flight_number,omitempty" xml:"operating_flight_number"`        // optional
-	AlternativeFlightsUrl string        `json:"alternate_flights_url,omitempty" xml:"alternate_flights_url"`            // optional, read-only
-	Aircraft              string        `json:"aircraft,omitempty" xml:"aircraft"`                                      // optional
-	AircraftDisplayName   string        `json:"aircraft_display_name,omitempty" xml:"aircraft_display_name"`            // optional, read-only
-	Distance              string        `json:"distance,omitempty" xml:"distance"`                                      // optional
-	Duration              string        `json:"duration,omitempty" xml:"duration"`                                      // optional
-	Entertainment         string        `json:"entertainment,omitempty" xml:"entertainment"`                            // optional
-	Meal                  string        `json:"meal,omitempty" xml:"meal"`                                              // optional
-	Notes                 string        `json:"notes,omitempty" xml:"notes"`                                            // optional
-	OntimePerc            string        `json:"ontime_perc,omitempty" xml:"ontime_perc"`                                // optional
-	Seats                 string        `json:"seats,omitempty" xml:"seats"`                                            // optional
-	ServiceClass          string        `json:"service_class,omitempty" xml:"service_class"`                            // optional
-	Stops                 string        `json:"stops,omitempty" xml:"stops"`                                            // optional
-	BaggageClaim          string        `json:"baggage_claim,omitempty" xml:"baggage_claim"`                            // optional
-	CheckInUrl            string        `json:"check_in_url,omitempty" xml:"check_in_url"`                              // optional
-	ConflictResolutionUrl string        `json:"conflict_resolution_url,omitempty" xml:"conflict_resolution_url"`        // optional, read-only
-	IsHidden              bool          `json:"is_hidden,string,omitempty" xml:"is_hidden"`                             // optional, read-only
-	Id                    string        `json:"id,omitempty" xml:"id"`                                                  // optional, read-only
+	Status                *FlightStatus `json:"Status,omitempty" xml:"Status,omitempty"`                                          // optional
+	StartDateTime         *DateTime     `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`                            // optional
+	EndDateTime           *DateTime     `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`                                // optional
+	StartAirportCode      string        `json:"start_airport_code,omitempty" xml:"start_airport_code,omitempty"`                  // optional
+	StartAirportLatitude  float64       `json:"start_airport_latitude,string,omitempty" xml:"start_airport_latitude,omitempty"`   // optional, read-only
+	StartAirportLongitude float64       `json:"start_airport_longitude,string,omitempty" xml:"start_airport_longitude,omitempty"` // optional, read-only
+	StartCityName         string        `json:"start_city_name,omitempty" xml:"start_city_name,omitempty"`                        // optional
+	StartGate             string        `json:"start_gate,omitempty" xml:"start_gate,omitempty"`                                  // optional
+	StartTerminal         string        `json:"start_terminal,omitempty" xml:"start_terminal,omitempty"`                          // optional
+	EndAirportCode        string        `json:"end_airport_code,omitempty" xml:"end_airport_code,omitempty"`                      // optional
+	EndAirportLatitude    float64       `json:"end_airport_latitude,string,omitempty" xml:"end_airport_latitude,omitempty"`       // optional, read-only
+	EndAirportLongitude   float64       `json:"end_airport_longitude,string,omitempty" xml:"end_airport_longitude,omitempty"`     // optional, read-only
+	EndCityName           string        `json:"end_city_name,omitempty" xml:"end_city_name,omitempty"`                            // optional
+	EndGate               string        `json:"end_gate,omitempty" xml:"end_gate,omitempty"`                                      // optional
+	EndTerminal           string        `json:"end_terminal,omitempty" xml:"end_terminal,omitempty"`                              // optional
+	MarketingAirline      string        `json:"marketing_airline,omitempty" xml:"marketing_airline,omitempty"`                    // optional
+	MarketingAirlineCode  string        `json:"marketing_airline_code,omitempty" xml:"marketing_airline_code,omitempty"`          // optional, read-only
+	MarketingFlightNumber string        `json:"marketing_flight_number,omitempty" xml:"marketing_flight_number,omitempty"`        // optional
+	OperatingAirline      string        `json:"operating_airline,omitempty" xml:"operating_airline,omitempty"`                    // optional
+	OperatingAirlineCode  string        `json:"operating_airline_code,omitempty" xml:"operating_airline_code,omitempty"`          // optional, read-only
+	OperatingFlightNumber string        `json:"operating_flight_number,omitempty" xml:"operating_flight_number,omitempty"`        // optional
+	AlternativeFlightsUrl string        `json:"alternate_flights_url,omitempty" xml:"alternate_flights_url,omitempty"`            // optional, read-only
+	Aircraft              string        `json:"aircraft,omitempty" xml:"aircraft,omitempty"`                                      // optional
+	AircraftDisplayName   string        `json:"aircraft_display_name,omitempty" xml:"aircraft_display_name,omitempty"`            // optional, read-only
+	Distance              string        `json:"distance,omitempty" xml:"distance,omitempty"`                                      // optional
+	Duration              string        `json:"duration,omitempty" xml:"duration,omitempty"`                                      // optional
+	Entertainment         string        `json:"entertainment,omitempty" xml:"entertainment,omitempty"`                            // optional
+	Meal                  string        `json:"meal,omitempty" xml:"meal,omitempty"`                                              // optional
+	Notes                 string        `json:"notes,omitempty" xml:"notes,omitempty"`                                            // optional
+	OntimePerc            string        `json:"ontime_perc,omitempty" xml:"ontime_perc,omitempty"`                                // optional
+	Seats                 string        `json:"seats,omitempty" xml:"seats,omitempty"`                                            // optional
+	ServiceClass          string        `json:"service_class,omitempty" xml:"service_class,omitempty"`                            // optional
+	Stops                 string        `json:"stops,omitempty" xml:"stops,omitempty"`                                            // optional
+	BaggageClaim          string        `json:"baggage_claim,omitempty" xml:"baggage_claim,omitempty"`                            // optional
+	CheckInUrl            string        `json:"check_in_url,omitempty" xml:"check_in_url,omitempty"`                              // optional
+	ConflictResolutionUrl string        `json:"conflict_resolution_url,omitempty" xml:"conflict_resolution_url,omitempty"`        // optional, read-only
+	IsHidden              bool          `json:"is_hidden,string,omitempty" xml:"is_hidden,omitempty"`                             // optional, read-only
+	Id                    string        `json:"id,omitempty" xml:"id,omitempty"`                                                  // optional, read-only
 }
 
 // LodgingObject contains information about hotels or other lodging.
@@ -525,43 +600,42 @@ type AirSegment struct {
 // hotel room description should be in notes.
 // hotel average daily rate should be in booking_rate.
 type LodgingObject struct {
-	Id                   string            `json:"id,omitempty" xml:"id"`                                         // optional, read-only
-	TripId               string            `json:"trip_id,omitempty" xml:"trip_id"`                               // optional
-	IsClientTraveler     bool              `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler"`  // optional, read-only
-	RelativeUrl          string            `json:"relative_url,omitempty" xml:"relative_url"`                     // optional, read-only
-	DisplayName          string            `json:"display_name,omitempty" xml:"display_name"`                     // optional
-	Image                ImagePtrVector    `json:"Image,omitempty" xml:"Image"`                                   // optional
-	CancellationDateTime *DateTime         `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime"`     // optional
-	BookingDate          string            `json:"booking_date,omitempty" xml:"booking_date"`                     // optional, xs:date
-	BookingRate          string            `json:"booking_rate,omitempty" xml:"booking_rate"`                     // optional
-	BookingSiteConfNum   string            `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num"`   // optional
-	BookingSiteName      string            `json:"booking_site_name,omitempty" xml:"booking_site_name"`           // optional
-	BookingSitePhone     string            `json:"booking_site_phone,omitempty" xml:"booking_site_phone"`         // optional
-	BookingSiteUrl       string            `json:"booking_site_url,omitempty" xml:"booking_site_url"`             // optional
-	RecordLocator        string            `json:"record_locator,omitempty" xml:"record_locator"`                 // optional
-	SupplierConfNum      string            `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num"`           // optional
-	SupplierContact      string            `json:"supplier_contact,omitempty" xml:"supplier_contact"`             // optional
-	SupplierEmailAddress string            `json:"supplier_email_address,omitempty" xml:"supplier_email_address"` // optional
-	SupplierName         string            `json:"supplier_name,omitempty" xml:"supplier_name"`                   // optional
-	SupplierPhone        string            `json:"supplier_phone,omitempty" xml:"supplier_phone"`                 // optional
-	SupplierUrl          string            `json:"supplier_url,omitempty" xml:"supplier_url"`                     // optional
-	IsPurchased          bool              `json:"is_purchased,string,omitempty" xml:"is_purchased"`              // optional
-	Notes                string            `json:"notes,omitempty" xml:"notes"`                                   // optional
-	Restrictions         string            `json:"restrictions,omitempty" xml:"restrictions"`                     // optional
-	TotalCost            string            `json:"total_cost,omitempty" xml:"total_cost"`                         // optional
-	StartDateTime        *DateTime         `json:"StartDateTime,omitempty" xml:"StartDateTime"`                   // optional
-	EndDateTime          *DateTime         `json:"EndDateTime,omitempty" xml:"EndDateTime"`                       // optional
-	Address              *Address          `json:"Address,omitempty" xml:"Address"`                               // optional
-	Guest                TravelerPtrVector `json:"Guest,omitempty" xml:"Guest"`                                   // optional
-	NumberGuests         string            `json:"number_guests,omitempty" xml:"number_guests"`                   // optional
-	NumberRooms          string            `json:"number_rooms,omitempty" xml:"number_rooms"`                     // optional
-	RoomType             string            `json:"room_type,omitempty" xml:"room_type"`                           // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string            `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string            `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool              `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string            `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string            `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector    `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime         `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date              `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string            `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string            `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string            `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string            `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string            `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string            `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string            `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string            `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string            `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string            `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string            `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string            `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool              `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string            `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string            `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string            `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	StartDateTime        *DateTime         `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`                   // optional
+	EndDateTime          *DateTime         `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`                       // optional
+	Address              *Address          `json:"Address,omitempty" xml:"Address,omitempty"`                               // optional
+	Guest                TravelerPtrVector `json:"Guest,omitempty" xml:"Guest,omitempty"`                                   // optional
+	NumberGuests         string            `json:"number_guests,omitempty" xml:"number_guests,omitempty"`                   // optional
+	NumberRooms          string            `json:"number_rooms,omitempty" xml:"number_rooms,omitempty"`                     // optional
+	RoomType             string            `json:"room_type,omitempty" xml:"room_type,omitempty"`                           // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *LodgingObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // CarObject contains information about rental cars.
@@ -569,104 +643,102 @@ func (r *LodgingObject) BookingTime() (time.Time, error) {
 // car pickup instructions should be in notes.
 // car daily rate should be in booking_rate.
 type CarObject struct {
-	Id                   string            `json:"id,omitempty" xml:"id"`                                         // optional, read-only
-	TripId               string            `json:"trip_id,omitempty" xml:"trip_id"`                               // optional
-	IsClientTraveler     bool              `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler"`  // optional, read-only
-	RelativeUrl          string            `json:"relative_url,omitempty" xml:"relative_url"`                     // optional, read-only
-	DisplayName          string            `json:"display_name,omitempty" xml:"display_name"`                     // optional
-	Image                ImagePtrVector    `json:"Image,omitempty" xml:"Image"`                                   // optional
-	CancellationDateTime *DateTime         `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime"`     // optional
-	BookingDate          string            `json:"booking_date,omitempty" xml:"booking_date"`                     // optional, xs:date
-	BookingRate          string            `json:"booking_rate,omitempty" xml:"booking_rate"`                     // optional
-	BookingSiteConfNum   string            `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num"`   // optional
-	BookingSiteName      string            `json:"booking_site_name,omitempty" xml:"booking_site_name"`           // optional
-	BookingSitePhone     string            `json:"booking_site_phone,omitempty" xml:"booking_site_phone"`         // optional
-	BookingSiteUrl       string            `json:"booking_site_url,omitempty" xml:"booking_site_url"`             // optional
-	RecordLocator        string            `json:"record_locator,omitempty" xml:"record_locator"`                 // optional
-	SupplierConfNum      string            `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num"`           // optional
-	SupplierContact      string            `json:"supplier_contact,omitempty" xml:"supplier_contact"`             // optional
-	SupplierEmailAddress string            `json:"supplier_email_address,omitempty" xml:"supplier_email_address"` // optional
-	SupplierName         string            `json:"supplier_name,omitempty" xml:"supplier_name"`                   // optional
-	SupplierPhone        string            `json:"supplier_phone,omitempty" xml:"supplier_phone"`                 // optional
-	SupplierUrl          string            `json:"supplier_url,omitempty" xml:"supplier_url"`                     // optional
-	IsPurchased          bool              `json:"is_purchased,string,omitempty" xml:"is_purchased"`              // optional
-	Notes                string            `json:"notes,omitempty" xml:"notes"`                                   // optional
-	Restrictions         string            `json:"restrictions,omitempty" xml:"restrictions"`                     // optional
-	TotalCost            string            `json:"total_cost,omitempty" xml:"total_cost"`                         // optional
-	StartDateTime        *DateTime         `json:"StartDateTime,omitempty" xml:"StartDateTime"`                   // optional
-	EndDateTime          *DateTime         `json:"EndDateTime,omitempty" xml:"EndDateTime"`                       // optional
-	StartLocationAddress *Address          `json:"StartLocationAddress,omitempty" xml:"StartLocationAddress"`     // optional
-	EndLocationAddress   *Address          `json:"EndLocationAddress,omitempty" xml:"EndLocationAddress"`         // optional
-	Driver               TravelerPtrVector `json:"Driver,omitempty" xml:"Driver"`                                 // optional
-	StartLocationHours   string            `json:"start_location_hours,omitempty" xml:"start_location_hours"`     // optional
-	StartLocationName    string            `json:"start_location_name,omitempty" xml:"start_location_name"`       // optional
-	StartLocationPhone   string            `json:"start_location_phone,omitempty" xml:"start_location_phone"`     // optional
-	EndLocationHours     string            `json:"end_location_hours,omitempty" xml:"end_location_hours"`         // optional
-	EndLocationName      string            `json:"end_location_name,omitempty" xml:"end_location_name"`           // optional
-	EndLocationPhone     string            `json:"end_location_phone,omitempty" xml:"end_location_phone"`         // optional
-	CarDescription       string            `json:"car_description,omitempty" xml:"car_description"`               // optional
-	CarType              string            `json:"car_type,omitempty" xml:"car_type"`                             // optional
-	MileageCharges       string            `json:"mileage_charges,omitempty" xml:"mileage_charges"`               // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string            `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string            `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool              `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string            `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string            `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector    `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime         `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date              `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string            `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string            `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string            `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string            `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string            `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string            `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string            `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string            `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string            `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string            `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string            `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string            `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool              `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string            `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string            `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string            `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	StartDateTime        *DateTime         `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`                   // optional
+	EndDateTime          *DateTime         `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`                       // optional
+	StartLocationAddress *Address          `json:"StartLocationAddress,omitempty" xml:"StartLocationAddress,omitempty"`     // optional
+	EndLocationAddress   *Address          `json:"EndLocationAddress,omitempty" xml:"EndLocationAddress,omitempty"`         // optional
+	Driver               TravelerPtrVector `json:"Driver,omitempty" xml:"Driver,omitempty"`                                 // optional
+	StartLocationHours   string            `json:"start_location_hours,omitempty" xml:"start_location_hours,omitempty"`     // optional
+	StartLocationName    string            `json:"start_location_name,omitempty" xml:"start_location_name,omitempty"`       // optional
+	StartLocationPhone   string            `json:"start_location_phone,omitempty" xml:"start_location_phone,omitempty"`     // optional
+	EndLocationHours     string            `json:"end_location_hours,omitempty" xml:"end_location_hours,omitempty"`         // optional
+	EndLocationName      string            `json:"end_location_name,omitempty" xml:"end_location_name,omitempty"`           // optional
+	EndLocationPhone     string            `json:"end_location_phone,omitempty" xml:"end_location_phone,omitempty"`         // optional
+	CarDescription       string            `json:"car_description,omitempty" xml:"car_description,omitempty"`               // optional
+	CarType              string            `json:"car_type,omitempty" xml:"car_type,omitempty"`                             // optional
+	MileageCharges       string            `json:"mileage_charges,omitempty" xml:"mileage_charges,omitempty"`               // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *CarObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // RailObject contains information about trains.
 type RailObject struct {
-	Id                   string               `json:"id,omitempty"`                        // optional, read-only
-	TripId               string               `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler     bool                 `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl          string               `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName          string               `json:"display_name,omitempty"`              // optional
-	Image                ImagePtrVector       `json:"Image,omitempty"`                     // optional
-	CancellationDateTime *DateTime            `json:"CancellationDateTime,omitempty"`      // optional
-	BookingDate          string               `json:"booking_date,omitempty"`              // optional, xs:date
-	BookingRate          string               `json:"booking_rate,omitempty"`              // optional
-	BookingSiteConfNum   string               `json:"booking_site_conf_num,omitempty"`     // optional
-	BookingSiteName      string               `json:"booking_site_name,omitempty"`         // optional
-	BookingSitePhone     string               `json:"booking_site_phone,omitempty"`        // optional
-	BookingSiteUrl       string               `json:"booking_site_url,omitempty"`          // optional
-	RecordLocator        string               `json:"record_locator,omitempty"`            // optional
-	SupplierConfNum      string               `json:"supplier_conf_num,omitempty"`         // optional
-	SupplierContact      string               `json:"supplier_contact,omitempty"`          // optional
-	SupplierEmailAddress string               `json:"supplier_email_address,omitempty"`    // optional
-	SupplierName         string               `json:"supplier_name,omitempty"`             // optional
-	SupplierPhone        string               `json:"supplier_phone,omitempty"`            // optional
-	SupplierUrl          string               `json:"supplier_url,omitempty"`              // optional
-	IsPurchased          bool                 `json:"is_purchased,string,omitempty"`       // optional
-	Notes                string               `json:"notes,omitempty"`                     // optional
-	Restrictions         string               `json:"restrictions,omitempty"`              // optional
-	TotalCost            string               `json:"total_cost,omitempty"`                // optional
-	Segment              RailSegmentPtrVector `json:"Segment,omitempty"`
-	Traveler             TravelerPtrVector    `json:"Traveler,omitempty"` // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string               `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string               `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool                 `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string               `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string               `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector       `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime            `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date                 `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string               `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string               `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string               `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string               `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string               `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string               `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string               `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string               `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string               `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string               `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string               `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string               `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool                 `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string               `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string               `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string               `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	Segment              RailSegmentPtrVector `json:"Segment,omitempty" xml:"Segment,omitempty"`
+	Traveler             TravelerPtrVector    `json:"Traveler,omitempty" xml:"Traveler,omitempty"` // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *RailObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // RailSegment contains details about an indivual train ride.
 type RailSegment struct {
-	StartDateTime       *DateTime `json:"StartDateTime,omitempty"`       // optional
-	EndDateTime         *DateTime `json:"EndDateTime,omitempty"`         // optional
-	StartStationAddress *Address  `json:"StartStationAddress,omitempty"` // optional
-	EndStationAddress   *Address  `json:"EndStationAddress,omitempty"`   // optional
-	StartStationName    string    `json:"start_station_name,omitempty"`  // optional
-	EndStationName      string    `json:"end_station_name,omitempty"`    // optional
-	CarrierName         string    `json:"carrier_name,omitempty"`        // optional
-	CoachNumber         string    `json:"coach_number,omitempty"`        // optional
-	ConfirmationNum     string    `json:"confirmation_num,omitempty"`    // optional
-	Seats               string    `json:"seats,omitempty"`               // optional
-	ServiceClass        string    `json:"service_class,omitempty"`       // optional
-	TrainNumber         string    `json:"train_number,omitempty"`        // optional
-	TrainType           string    `json:"train_type,omitempty"`          // optional
-	Id                  string    `json:"id,omitempty"`                  // optional, read-only
+	StartDateTime       *DateTime `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`             // optional
+	EndDateTime         *DateTime `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`                 // optional
+	StartStationAddress *Address  `json:"StartStationAddress,omitempty" xml:"StartStationAddress,omitempty"` // optional
+	EndStationAddress   *Address  `json:"EndStationAddress,omitempty" xml:"EndStationAddress,omitempty"`     // optional
+	StartStationName    string    `json:"start_station_name,omitempty" xml:"start_station_name,omitempty"`   // optional
+	EndStationName      string    `json:"end_station_name,omitempty" xml:"end_station_name,omitempty"`       // optional
+	CarrierName         string    `json:"carrier_name,omitempty" xml:"carrier_name,omitempty"`               // optional
+	CoachNumber         string    `json:"coach_number,omitempty" xml:"coach_number,omitempty"`               // optional
+	ConfirmationNum     string    `json:"confirmation_num,omitempty" xml:"confirmation_num,omitempty"`       // optional
+	Seats               string    `json:"seats,omitempty" xml:"seats,omitempty"`                             // optional
+	ServiceClass        string    `json:"service_class,omitempty" xml:"service_class,omitempty"`             // optional
+	TrainNumber         string    `json:"train_number,omitempty" xml:"train_number,omitempty"`               // optional
+	TrainType           string    `json:"train_type,omitempty" xml:"train_type,omitempty"`                   // optional
+	Id                  string    `json:"id,omitempty" xml:"id,omitempty"`                                   // optional, read-only
 }
 
 // Transport Detail Types
@@ -677,54 +749,53 @@ const (
 
 // TransportObject contains details about other forms of transport like bus rides.
 type TransportObject struct {
-	Id                   string                    `json:"id,omitempty"`                        // optional, read-only
-	TripId               string                    `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler     bool                      `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl          string                    `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName          string                    `json:"display_name,omitempty"`              // optional
-	Image                ImagePtrVector            `json:"Image,omitempty"`                     // optional
-	CancellationDateTime *DateTime                 `json:"CancellationDateTime,omitempty"`      // optional
-	BookingDate          string                    `json:"booking_date,omitempty"`              // optional, xs:date
-	BookingRate          string                    `json:"booking_rate,omitempty"`              // optional
-	BookingSiteConfNum   string                    `json:"booking_site_conf_num,omitempty"`     // optional
-	BookingSiteName      string                    `json:"booking_site_name,omitempty"`         // optional
-	BookingSitePhone     string                    `json:"booking_site_phone,omitempty"`        // optional
-	BookingSiteUrl       string                    `json:"booking_site_url,omitempty"`          // optional
-	RecordLocator        string                    `json:"record_locator,omitempty"`            // optional
-	SupplierConfNum      string                    `json:"supplier_conf_num,omitempty"`         // optional
-	SupplierContact      string                    `json:"supplier_contact,omitempty"`          // optional
-	SupplierEmailAddress string                    `json:"supplier_email_address,omitempty"`    // optional
-	SupplierName         string                    `json:"supplier_name,omitempty"`             // optional
-	SupplierPhone        string                    `json:"supplier_phone,omitempty"`            // optional
-	SupplierUrl          string                    `json:"supplier_url,omitempty"`              // optional
-	IsPurchased          bool                      `json:"is_purchased,string,omitempty"`       // optional
-	Notes                string                    `json:"notes,omitempty"`                     // optional
-	Restrictions         string                    `json:"restrictions,omitempty"`              // optional
-	TotalCost            string                    `json:"total_cost,omitempty"`                // optional
-	Segment              TransportSegmentPtrVector `json:"Segment,omitempty"`
-	Traveler             TravelerPtrVector         `json:"Traveler,omitempty"` // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information
+	Id                   string                    `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string                    `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool                      `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string                    `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string                    `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector            `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime                 `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date                      `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string                    `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string                    `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string                    `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string                    `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string                    `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string                    `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string                    `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string                    `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string                    `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string                    `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string                    `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string                    `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool                      `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string                    `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string                    `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string                    `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	Segment              TransportSegmentPtrVector `json:"Segment,omitempty" xml:"Segment,omitempty"`
+	Traveler             TravelerPtrVector         `json:"Traveler,omitempty" xml:"Traveler,omitempty"` // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *TransportObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // TransportSegment contains details about indivual transport rides.
 type TransportSegment struct {
-	StartDateTime        *DateTime `json:"StartDateTime,omitempty"`        // optional
-	EndDateTime          *DateTime `json:"EndDateTime,omitempty"`          // optional
-	StartLocationAddress *Address  `json:"StartLocationAddress,omitempty"` // optional
-	EndLocationAddress   *Address  `json:"EndLocationAddress,omitempty"`   // optional
-	StartLocationName    string    `json:"start_location_name,omitempty"`  // optional
-	EndLocationName      string    `json:"end_location_name,omitempty"`    // optional
-	DetailTypeCode       string    `json:"detail_type_code,omitempty"`     // optional
-	CarrierName          string    `json:"carrier_name,omitempty"`         // optional
-	ConfirmationNum      string    `json:"confirmation_num,omitempty"`     // optional
-	NumberPassengers     string    `json:"number_passengers,omitempty"`    // optional
-	VehicleDescription   string    `json:"vehicle_description,omitempty"`  // optional
-	Id                   string    `json:"id,omitempty"`                   // optional, read-only
+	StartDateTime        *DateTime `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`               // optional
+	EndDateTime          *DateTime `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`                   // optional
+	StartLocationAddress *Address  `json:"StartLocationAddress,omitempty" xml:"StartLocationAddress,omitempty"` // optional
+	EndLocationAddress   *Address  `json:"EndLocationAddress,omitempty" xml:"EndLocationAddress,omitempty"`     // optional
+	StartLocationName    string    `json:"start_location_name,omitempty" xml:"start_location_name,omitempty"`   // optional
+	EndLocationName      string    `json:"end_location_name,omitempty" xml:"end_location_name,omitempty"`       // optional
+	DetailTypeCode       string    `json:"detail_type_code,omitempty" xml:"detail_type_code,omitempty"`         // optional
+	CarrierName          string    `json:"carrier_name,omitempty" xml:"carrier_name,omitempty"`                 // optional
+	ConfirmationNum      string    `json:"confirmation_num,omitempty" xml:"confirmation_num,omitempty"`         // optional
+	NumberPassengers     string    `json:"number_passengers,omitempty" xml:"number_passengers,omitempty"`       // optional
+	VehicleDescription   string    `json:"vehicle_description,omitempty" xml:"vehicle_description,omitempty"`   // optional
+	Id                   string    `json:"id,omitempty" xml:"id,omitempty"`                                     // optional, read-only
 }
 
 // Cruise Detail Types
@@ -734,96 +805,94 @@ const (
 
 // CruiseObject contains information about cruises.
 type CruiseObject struct {
-	Id                   string                 `json:"id,omitempty"`                        // optional, read-only
-	TripId               string                 `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler     bool                   `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl          string                 `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName          string                 `json:"display_name,omitempty"`              // optional
-	Image                ImagePtrVector         `json:"Image,omitempty"`                     // optional
-	CancellationDateTime *DateTime              `json:"CancellationDateTime,omitempty"`      // optional
-	BookingDate          string                 `json:"booking_date,omitempty"`              // optional, xs:date
-	BookingRate          string                 `json:"booking_rate,omitempty"`              // optional
-	BookingSiteConfNum   string                 `json:"booking_site_conf_num,omitempty"`     // optional
-	BookingSiteName      string                 `json:"booking_site_name,omitempty"`         // optional
-	BookingSitePhone     string                 `json:"booking_site_phone,omitempty"`        // optional
-	BookingSiteUrl       string                 `json:"booking_site_url,omitempty"`          // optional
-	RecordLocator        string                 `json:"record_locator,omitempty"`            // optional
-	SupplierConfNum      string                 `json:"supplier_conf_num,omitempty"`         // optional
-	SupplierContact      string                 `json:"supplier_contact,omitempty"`          // optional
-	SupplierEmailAddress string                 `json:"supplier_email_address,omitempty"`    // optional
-	SupplierName         string                 `json:"supplier_name,omitempty"`             // optional
-	SupplierPhone        string                 `json:"supplier_phone,omitempty"`            // optional
-	SupplierUrl          string                 `json:"supplier_url,omitempty"`              // optional
-	IsPurchased          bool                   `json:"is_purchased,string,omitempty"`       // optional
-	Notes                string                 `json:"notes,omitempty"`                     // optional
-	Restrictions         string                 `json:"restrictions,omitempty"`              // optional
-	TotalCost            string                 `json:"total_cost,omitempty"`                // optional
-	Segment              CruiseSegmentPtrVector `json:"Segment,omitempty"`
-	Traveler             TravelerPtrVector      `json:"Traveler,omitempty"`     // optional
-	CabinNumber          string                 `json:"cabin_number,omitempty"` // optional
-	CabinType            string                 `json:"cabin_type,omitempty"`   // optional
-	Dining               string                 `json:"dining,omitempty"`       // optional
-	ShipName             string                 `json:"ship_name,omitempty"`    // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string                 `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string                 `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool                   `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string                 `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string                 `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector         `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime              `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date                   `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string                 `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string                 `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string                 `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string                 `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string                 `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string                 `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string                 `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string                 `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string                 `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string                 `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string                 `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string                 `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool                   `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string                 `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string                 `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string                 `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	Segment              CruiseSegmentPtrVector `json:"Segment,omitempty" xml:"Segment,omitempty"`
+	Traveler             TravelerPtrVector      `json:"Traveler,omitempty" xml:"Traveler,omitempty"`         // optional
+	CabinNumber          string                 `json:"cabin_number,omitempty" xml:"cabin_number,omitempty"` // optional
+	CabinType            string                 `json:"cabin_type,omitempty" xml:"cabin_type,omitempty"`     // optional
+	Dining               string                 `json:"dining,omitempty" xml:"dining,omitempty"`             // optional
+	ShipName             string                 `json:"ship_name,omitempty" xml:"ship_name,omitempty"`       // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *CruiseObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // CruiseSegment contains details about indivual cruise segments.
 type CruiseSegment struct {
-	StartDateTime   *DateTime `json:"StartDateTime,omitempty"`    // optional
-	EndDateTime     *DateTime `json:"EndDateTime,omitempty"`      // optional
-	LocationAddress *Address  `json:"LocationAddress,omitempty"`  // optional
-	LocationName    string    `json:"location_name,omitempty"`    // optional
-	DetailTypeCode  string    `json:"detail_type_code,omitempty"` // optional
-	Id              string    `json:"id,omitempty"`               // optional, read-only
+	StartDateTime   *DateTime `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`       // optional
+	EndDateTime     *DateTime `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`           // optional
+	LocationAddress *Address  `json:"LocationAddress,omitempty" xml:"LocationAddress,omitempty"`   // optional
+	LocationName    string    `json:"location_name,omitempty" xml:"location_name,omitempty"`       // optional
+	DetailTypeCode  string    `json:"detail_type_code,omitempty" xml:"detail_type_code,omitempty"` // optional
+	Id              string    `json:"id,omitempty" xml:"id,omitempty"`                             // optional, read-only
 }
 
 // RestaurantObject contains details about dining reservations.
 // restaurant name should be in supplier_name.
 // restaurant notes should be in notes.
 type RestaurantObject struct {
-	Id                   string         `json:"id,omitempty"`                        // optional, read-only
-	TripId               string         `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler     bool           `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl          string         `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName          string         `json:"display_name,omitempty"`              // optional
-	Image                ImagePtrVector `json:"Image,omitempty"`                     // optional
-	CancellationDateTime *DateTime      `json:"CancellationDateTime,omitempty"`      // optional
-	BookingDate          string         `json:"booking_date,omitempty"`              // optional, xs:date
-	BookingRate          string         `json:"booking_rate,omitempty"`              // optional
-	BookingSiteConfNum   string         `json:"booking_site_conf_num,omitempty"`     // optional
-	BookingSiteName      string         `json:"booking_site_name,omitempty"`         // optional
-	BookingSitePhone     string         `json:"booking_site_phone,omitempty"`        // optional
-	BookingSiteUrl       string         `json:"booking_site_url,omitempty"`          // optional
-	RecordLocator        string         `json:"record_locator,omitempty"`            // optional
-	SupplierConfNum      string         `json:"supplier_conf_num,omitempty"`         // optional
-	SupplierContact      string         `json:"supplier_contact,omitempty"`          // optional
-	SupplierEmailAddress string         `json:"supplier_email_address,omitempty"`    // optional
-	SupplierName         string         `json:"supplier_name,omitempty"`             // optional
-	SupplierPhone        string         `json:"supplier_phone,omitempty"`            // optional
-	SupplierUrl          string         `json:"supplier_url,omitempty"`              // optional
-	IsPurchased          bool           `json:"is_purchased,string,omitempty"`       // optional
-	Notes                string         `json:"notes,omitempty"`                     // optional
-	Restrictions         string         `json:"restrictions,omitempty"`              // optional
-	TotalCost            string         `json:"total_cost,omitempty"`                // optional
-	DateTime             *DateTime      `json:"DateTime,omitempty"`                  // optional
-	Address              *Address       `json:"Address,omitempty"`                   // optional
-	ReservationHolder    *Traveler      `json:"ReservationHolder,omitempty"`         // optional
-	Cuisine              string         `json:"cuisine,omitempty"`                   // optional
-	DressCode            string         `json:"dress_code,omitempty"`                // optional
-	Hours                string         `json:"hours,omitempty"`                     // optional
-	NumberPatrons        string         `json:"number_patrons,omitempty"`            // optional
-	PriceRange           string         `json:"price_range,omitempty"`               // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string         `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string         `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool           `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string         `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string         `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime      `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date           `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string         `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string         `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string         `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string         `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string         `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string         `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string         `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string         `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string         `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string         `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string         `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string         `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool           `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string         `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string         `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string         `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	DateTime             *DateTime      `json:"DateTime,omitempty" xml:"DateTime,omitempty"`                             // optional
+	Address              *Address       `json:"Address,omitempty" xml:"Address,omitempty"`                               // optional
+	ReservationHolder    *Traveler      `json:"ReservationHolder,omitempty" xml:"ReservationHolder,omitempty"`           // optional
+	Cuisine              string         `json:"cuisine,omitempty" xml:"cuisine,omitempty"`                               // optional
+	DressCode            string         `json:"dress_code,omitempty" xml:"dress_code,omitempty"`                         // optional
+	Hours                string         `json:"hours,omitempty" xml:"hours,omitempty"`                                   // optional
+	NumberPatrons        string         `json:"number_patrons,omitempty" xml:"number_patrons,omitempty"`                 // optional
+	PriceRange           string         `json:"price_range,omitempty" xml:"price_range,omitempty"`                       // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *RestaurantObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // Activity Detail Types
@@ -836,42 +905,42 @@ const (
 
 // ActivityObject contains details about activities like museum, theatre, and other events.
 type ActivityObject struct {
-	Id                   string            `json:"id,omitempty"`                        // optional, read-only
-	TripId               string            `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler     bool              `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl          string            `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName          string            `json:"display_name,omitempty"`              // optional
-	Image                ImagePtrVector    `json:"Image,omitempty"`                     // optional
-	CancellationDateTime *DateTime         `json:"CancellationDateTime,omitempty"`      // optional
-	BookingDate          string            `json:"booking_date,omitempty"`              // optional, xs:date
-	BookingRate          string            `json:"booking_rate,omitempty"`              // optional
-	BookingSiteConfNum   string            `json:"booking_site_conf_num,omitempty"`     // optional
-	BookingSiteName      string            `json:"booking_site_name,omitempty"`         // optional
-	BookingSitePhone     string            `json:"booking_site_phone,omitempty"`        // optional
-	BookingSiteUrl       string            `json:"booking_site_url,omitempty"`          // optional
-	RecordLocator        string            `json:"record_locator,omitempty"`            // optional
-	SupplierConfNum      string            `json:"supplier_conf_num,omitempty"`         // optional
-	SupplierContact      string            `json:"supplier_contact,omitempty"`          // optional
-	SupplierEmailAddress string            `json:"supplier_email_address,omitempty"`    // optional
-	SupplierName         string            `json:"supplier_name,omitempty"`             // optional
-	SupplierPhone        string            `json:"supplier_phone,omitempty"`            // optional
-	SupplierUrl          string            `json:"supplier_url,omitempty"`              // optional
-	IsPurchased          bool              `json:"is_purchased,string,omitempty"`       // optional
-	Notes                string            `json:"notes,omitempty"`                     // optional
-	Restrictions         string            `json:"restrictions,omitempty"`              // optional
-	TotalCost            string            `json:"total_cost,omitempty"`                // optional
-	StartDateTime        *DateTime         `json:"StartDateTime,omitempty"`             // optional
-	EndTime              string            `json:"end_time,omitempty"`                  // optional, xs:time
-	Address              *Address          `json:"Address,omitempty"`                   // optional
-	Participant          TravelerPtrVector `json:"Participant,omitempty"`               // optional
-	DetailTypeCode       string            `json:"detail_type_code,omitempty"`          // optional
-	LocationName         string            `json:"location_name,omitempty"`             // optional
-}
-
-// BookingTime returns a time.Time object for BookingDate.
-// Note: This won't have proper time zone information.
+	Id                   string            `json:"id,omitempty" xml:"id,omitempty"`                                         // optional, read-only
+	TripId               string            `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                               // optional
+	IsClientTraveler     bool              `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`  // optional, read-only
+	RelativeUrl          string            `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                     // optional, read-only
+	DisplayName          string            `json:"display_name,omitempty" xml:"display_name,omitempty"`                     // optional
+	Image                ImagePtrVector    `json:"Image,omitempty" xml:"Image,omitempty"`                                   // optional
+	CancellationDateTime *DateTime         `json:"CancellationDateTime,omitempty" xml:"CancellationDateTime,omitempty"`     // optional
+	BookingDate          Date              `json:"booking_date,omitzero" xml:"booking_date"`                                // optional, xs:date
+	BookingRate          string            `json:"booking_rate,omitempty" xml:"booking_rate,omitempty"`                     // optional
+	BookingSiteConfNum   string            `json:"booking_site_conf_num,omitempty" xml:"booking_site_conf_num,omitempty"`   // optional
+	BookingSiteName      string            `json:"booking_site_name,omitempty" xml:"booking_site_name,omitempty"`           // optional
+	BookingSitePhone     string            `json:"booking_site_phone,omitempty" xml:"booking_site_phone,omitempty"`         // optional
+	BookingSiteUrl       string            `json:"booking_site_url,omitempty" xml:"booking_site_url,omitempty"`             // optional
+	RecordLocator        string            `json:"record_locator,omitempty" xml:"record_locator,omitempty"`                 // optional
+	SupplierConfNum      string            `json:"supplier_conf_num,omitempty" xml:"supplier_conf_num,omitempty"`           // optional
+	SupplierContact      string            `json:"supplier_contact,omitempty" xml:"supplier_contact,omitempty"`             // optional
+	SupplierEmailAddress string            `json:"supplier_email_address,omitempty" xml:"supplier_email_address,omitempty"` // optional
+	SupplierName         string            `json:"supplier_name,omitempty" xml:"supplier_name,omitempty"`                   // optional
+	SupplierPhone        string            `json:"supplier_phone,omitempty" xml:"supplier_phone,omitempty"`                 // optional
+	SupplierUrl          string            `json:"supplier_url,omitempty" xml:"supplier_url,omitempty"`                     // optional
+	IsPurchased          bool              `json:"is_purchased,string,omitempty" xml:"is_purchased,omitempty"`              // optional
+	Notes                string            `json:"notes,omitempty" xml:"notes,omitempty"`                                   // optional
+	Restrictions         string            `json:"restrictions,omitempty" xml:"restrictions,omitempty"`                     // optional
+	TotalCost            string            `json:"total_cost,omitempty" xml:"total_cost,omitempty"`                         // optional
+	StartDateTime        *DateTime         `json:"StartDateTime,omitempty" xml:"StartDateTime,omitempty"`                   // optional
+	EndDateTime          *DateTime         `json:"EndDateTime,omitempty" xml:"EndDateTime,omitempty"`                       // optional, for activities that end on a later day
+	EndTime              string            `json:"end_time,omitempty" xml:"end_time,omitempty"`                             // optional, xs:time
+	Address              *Address          `json:"Address,omitempty" xml:"Address,omitempty"`                               // optional
+	Participant          TravelerPtrVector `json:"Participant,omitempty" xml:"Participant,omitempty"`                       // optional
+	DetailTypeCode       string            `json:"detail_type_code,omitempty" xml:"detail_type_code,omitempty"`             // optional
+	LocationName         string            `json:"location_name,omitempty" xml:"location_name,omitempty"`                   // optional
+}
+
+// BookingTime returns a time.Time object for BookingDate, at midnight UTC.
 func (r *ActivityObject) BookingTime() (time.Time, error) {
-	return time.Parse("2006-01-02", r.BookingDate)
+	return r.BookingDate.utcTime()
 }
 
 // Note Detail Types
@@ -881,66 +950,65 @@ const (
 
 // NoteObject contains information about notes added by the traveler.
 type NoteObject struct {
-	Id               string         `json:"id,omitempty"`                        // optional, read-only
-	TripId           string         `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler bool           `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl      string         `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName      string         `json:"display_name,omitempty"`              // optional
-	Image            ImagePtrVector `json:"Image,omitempty"`                     // optional
-	DateTime         *DateTime      `json:"DateTime,omitempty"`                  // optional
-	Address          *Address       `json:"Address,omitempty"`                   // optional
-	DetailTypeCode   string         `json:"detail_type_code,omitempty"`          // optional
-	Source           string         `json:"source,omitempty"`                    // optional
-	Text             string         `json:"text,omitempty"`                      // optional
-	Url              string         `json:"url,omitempty"`                       // optional
-	Notes            string         `json:"notes,omitempty"`                     // optional
+	Id               string         `json:"id,omitempty" xml:"id,omitempty"`                                        // optional, read-only
+	TripId           string         `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                              // optional
+	IsClientTraveler bool           `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"` // optional, read-only
+	RelativeUrl      string         `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                    // optional, read-only
+	DisplayName      string         `json:"display_name,omitempty" xml:"display_name,omitempty"`                    // optional
+	Image            ImagePtrVector `json:"Image,omitempty" xml:"Image,omitempty"`                                  // optional
+	DateTime         *DateTime      `json:"DateTime,omitempty" xml:"DateTime,omitempty"`                            // optional
+	Address          *Address       `json:"Address,omitempty" xml:"Address,omitempty"`                              // optional
+	DetailTypeCode   string         `json:"detail_type_code,omitempty" xml:"detail_type_code,omitempty"`            // optional
+	Source           string         `json:"source,omitempty" xml:"source,omitempty"`                                // optional
+	Text             string         `json:"text,omitempty" xml:"text,omitempty"`                                    // optional
+	Url              string         `json:"url,omitempty" xml:"url,omitempty"`                                      // optional
+	Notes            string         `json:"notes,omitempty" xml:"notes,omitempty"`                                  // optional
 }
 
 // MapObject contains addresses to show on a map.
 type MapObject struct {
-	Id               string         `json:"id,omitempty"`                        // optional, read-only
-	TripId           string         `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler bool           `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl      string         `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName      string         `json:"display_name,omitempty"`              // optional
-	Image            ImagePtrVector `json:"Image,omitempty"`                     // optional
-	DateTime         *DateTime      `json:"DateTime,omitempty"`                  // optional
-	Address          *Address       `json:"Address,omitempty"`                   // optional
+	Id               string         `json:"id,omitempty" xml:"id,omitempty"`                                        // optional, read-only
+	TripId           string         `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                              // optional
+	IsClientTraveler bool           `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"` // optional, read-only
+	RelativeUrl      string         `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                    // optional, read-only
+	DisplayName      string         `json:"display_name,omitempty" xml:"display_name,omitempty"`                    // optional
+	Image            ImagePtrVector `json:"Image,omitempty" xml:"Image,omitempty"`                                  // optional
+	DateTime         *DateTime      `json:"DateTime,omitempty" xml:"DateTime,omitempty"`                            // optional
+	Address          *Address       `json:"Address,omitempty" xml:"Address,omitempty"`                              // optional
 }
 
 // DirectionsObject contains addresses to show directions for on the trip.
 type DirectionsObject struct {
-	Id               string         `json:"id,omitempty"`                        // optional, read-only
-	TripId           string         `json:"trip_id,omitempty"`                   // optional
-	IsClientTraveler bool           `json:"is_client_traveler,string,omitempty"` // optional, read-only
-	RelativeUrl      string         `json:"relative_url,omitempty"`              // optional, read-only
-	DisplayName      string         `json:"display_name,omitempty"`              // optional
-	Image            ImagePtrVector `json:"Image,omitempty"`                     // optional
-	DateTime         *DateTime      `json:"DateTime,omitempty"`                  // optional
-	StartAddress     *Address       `json:"StartAddress,omitempty"`              // optional
-	EndAddress       *Address       `json:"EndAddress,omitempty"`                // optional
+	Id               string         `json:"id,omitempty" xml:"id,omitempty"`                                        // optional, read-only
+	TripId           string         `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                              // optional
+	IsClientTraveler bool           `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"` // optional, read-only
+	RelativeUrl      string         `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                    // optional, read-only
+	DisplayName      string         `json:"display_name,omitempty" xml:"display_name,omitempty"`                    // optional
+	Image            ImagePtrVector `json:"Image,omitempty" xml:"Image,omitempty"`                                  // optional
+	DateTime         *DateTime      `json:"DateTime,omitempty" xml:"DateTime,omitempty"`                            // optional
+	StartAddress     *Address       `json:"StartAddress,omitempty" xml:"StartAddress,omitempty"`                    // optional
+	EndAddress       *Address       `json:"EndAddress,omitempty" xml:"EndAddress,omitempty"`                        // optional
 }
 
 // WeatherObject contains information about the weather at a particular destination.
 // Weather is read-only.
 type WeatherObject struct {
-	Id                 string         `json:"id,omitempty"`                          // optional, read-only
-	TripId             string         `json:"trip_id,omitempty"`                     // optional
-	IsClientTraveler   bool           `json:"is_client_traveler,string,omitempty"`   // optional, read-only
-	RelativeUrl        string         `json:"relative_url,omitempty"`                // optional, read-only
-	DisplayName        string         `json:"display_name,omitempty"`                // optional
-	Image              ImagePtrVector `json:"Image,omitempty"`                       // optional
-	Date               string         `json:"date,omitempty"`                        // optional, read-only, xs:date
-	Location           string         `json:"location,omitempty"`                    // optional, read-only
-	AvgHighTempC       float64        `json:"avg_high_temp_c,string,omitempty"`      // optional, read-only
-	AvgLowTempC        float64        `json:"avg_low_temp_c,string,omitempty"`       // optional, read-only
-	AvgWindSpeedKn     float64        `json:"avg_wind_speed_kn,string,omitempty"`    // optional, read-only
-	AvgPrecipitationCm float64        `json:"avg_precipitation_cm,string,omitempty"` // optional, read-only
-	AvgSnowDepthCm     float64        `json:"avg_snow_depth_cm,string,omitempty"`    // optional, read-only
-}
-
-// Time returns a time.Time object for StartDate.
-// Note: This won't have proper time zone information.
+	Id                 string         `json:"id,omitempty" xml:"id,omitempty"`                                            // optional, read-only
+	TripId             string         `json:"trip_id,omitempty" xml:"trip_id,omitempty"`                                  // optional
+	IsClientTraveler   bool           `json:"is_client_traveler,string,omitempty" xml:"is_client_traveler,omitempty"`     // optional, read-only
+	RelativeUrl        string         `json:"relative_url,omitempty" xml:"relative_url,omitempty"`                        // optional, read-only
+	DisplayName        string         `json:"display_name,omitempty" xml:"display_name,omitempty"`                        // optional
+	Image              ImagePtrVector `json:"Image,omitempty" xml:"Image,omitempty"`                                      // optional
+	Date               Date           `json:"date,omitzero" xml:"date"`                                                   // optional, read-only, xs:date
+	Location           string         `json:"location,omitempty" xml:"location,omitempty"`                                // optional, read-only
+	AvgHighTempC       float64        `json:"avg_high_temp_c,string,omitempty" xml:"avg_high_temp_c,omitempty"`           // optional, read-only
+	AvgLowTempC        float64        `json:"avg_low_temp_c,string,omitempty" xml:"avg_low_temp_c,omitempty"`             // optional, read-only
+	AvgWindSpeedKn     float64        `json:"avg_wind_speed_kn,string,omitempty" xml:"avg_wind_speed_kn,omitempty"`       // optional, read-only
+	AvgPrecipitationCm float64        `json:"avg_precipitation_cm,string,omitempty" xml:"avg_precipitation_cm,omitempty"` // optional, read-only
+	AvgSnowDepthCm     float64        `json:"avg_snow_depth_cm,string,omitempty" xml:"avg_snow_depth_cm,omitempty"`       // optional, read-only
+}
+
+// Time returns a time.Time object for Date, at midnight UTC.
 func (w *WeatherObject) Time() (time.Time, error) {
-	return time.Parse("2006-01-02", w.Date)
+	return w.Date.utcTime()
 }
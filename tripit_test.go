@@ -75,3 +75,62 @@ func TestDateTime(t *testing.T) {
 
 	log.Print("Assigned time: ", d)
 }
+
+func TestDateTimeZone(t *testing.T) {
+	// An arrival in Los Angeles should be in local time, even without an offset.
+	d := DateTime{Date: "2009-07-10", Time: "14:00:00", Timezone: "America/Los_Angeles"}
+	tm, err := d.GetTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Location().String() != "America/Los_Angeles" || tm.Hour() != 14 || tm.UTC().Hour() != 21 {
+		t.Errorf("Unexpected time: %v", tm)
+	}
+
+	// Round trip keeps the zone.
+	var d2 DateTime
+	d2.SetTime(tm)
+	if d2.Timezone != "America/Los_Angeles" || d2.UtcOffset != "-07:00" {
+		t.Errorf("Unexpected DateTime: %+v", d2)
+	}
+	tm2, err := d2.GetTime()
+	if err != nil || !tm2.Equal(tm) || tm2.Location().String() != tm.Location().String() {
+		t.Errorf("Round trip failed: %v, %v", tm2, err)
+	}
+
+	// Offset only uses a fixed zone.
+	d = DateTime{Date: "2009-11-10", Time: "14:00:00", UtcOffset: "+05:30"}
+	tm, err = d.GetTime()
+	if _, offset := tm.Zone(); err != nil || offset != 19800 {
+		t.Errorf("Unexpected fixed zone time: %v, %v", tm, err)
+	}
+
+	// Unknown zone names fall back to the offset.
+	d = DateTime{Date: "2009-11-10", Time: "14:00:00", Timezone: "MST7MDT/Nowhere", UtcOffset: "-07:00"}
+	if tm, err = d.GetTime(); err != nil || tm.UTC().Hour() != 21 {
+		t.Errorf("Unexpected fallback time: %v, %v", tm, err)
+	}
+
+	// UtcOffset selects the instant when a local time occurs twice.
+	for _, c := range []struct {
+		offset string
+		utc    int
+	}{{"-04:00", 5}, {"-05:00", 6}} {
+		d = DateTime{Date: "2011-11-06", Time: "01:30:00", Timezone: "America/New_York", UtcOffset: c.offset}
+		if tm, err = d.GetTime(); err != nil || tm.UTC().Hour() != c.utc || tm.Location().String() != "America/New_York" {
+			t.Errorf("Unexpected fall-back time for %s: %v, %v", c.offset, tm, err)
+		}
+	}
+
+	// An offset that doesn't match the zone is ignored.
+	d = DateTime{Date: "2011-11-07", Time: "01:30:00", Timezone: "America/New_York", UtcOffset: "-04:00"}
+	if tm, err = d.GetTime(); err != nil || tm.UTC().Hour() != 6 {
+		t.Errorf("Unexpected time with a mismatched offset: %v, %v", tm, err)
+	}
+
+	// Fixed zones are not written to Timezone.
+	d2.SetTime(time.Date(2009, 11, 10, 14, 0, 0, 0, time.FixedZone("XYZ", 3600)))
+	if d2.Timezone != "" || d2.UtcOffset != "+01:00" {
+		t.Errorf("Unexpected DateTime: %+v", d2)
+	}
+}
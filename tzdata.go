@@ -0,0 +1,7 @@
+//go:build tripit_tzdata
+
+package tripit
+
+// Embed the time zone database so that DateTime can load IANA time zones on systems
+// that don't have one installed.
+import _ "time/tzdata"
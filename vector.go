@@ -0,0 +1,93 @@
+package tripit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// OneOrMany is a list of values of type T. TripIt returns a single object when there is
+// one value and an array when there are several, so OneOrMany decodes an object, an array,
+// null or an empty string. For pointer types, null elements are dropped.
+type OneOrMany[T any] []T
+
+// UnmarshalJSON builds the list from the JSON in b. A null or empty string gives an empty list.
+func (p *OneOrMany[T]) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0, bytes.Equal(b, []byte("null")), bytes.Equal(b, []byte(`""`)):
+		*p = nil
+		return nil
+	case b[0] == '[':
+		if err := json.Unmarshal(b, (*[]T)(p)); err != nil {
+			return err
+		}
+		p.trimNil()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = OneOrMany[T]{v}
+	return nil
+}
+
+// trimNil removes nil elements, which come from null entries in an array, from a list
+// of pointers.
+func (p *OneOrMany[T]) trimNil() {
+	var zero T
+	if reflect.TypeOf(zero) == nil || reflect.TypeOf(zero).Kind() != reflect.Pointer {
+		return
+	}
+	s := (*p)[:0]
+	for _, v := range *p {
+		if !reflect.ValueOf(v).IsNil() {
+			s = append(s, v)
+		}
+	}
+	*p = s
+}
+
+// MarshalJSON encodes the list as an array. Use JsonSingleObjectCodec to encode a list
+// with one element as a single object.
+func (p OneOrMany[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]T(p))
+}
+
+// isOneOrMany marks OneOrMany types for JsonSingleObjectCodec.
+func (OneOrMany[T]) isOneOrMany() {}
+
+// Vector types used in the TripIt model
+type (
+	ErrorVector                   = OneOrMany[Error]
+	WarningVector                 = OneOrMany[Warning]
+	ClosenessMatchVector          = OneOrMany[ClosenessMatch]
+	GroupVector                   = OneOrMany[Group]
+	InviteeVector                 = OneOrMany[Invitee]
+	ProfileEmailAddressVector     = OneOrMany[ProfileEmailAddress]
+	TripCrsRemarkVector           = OneOrMany[TripCrsRemark]
+	TripPtrVector                 = OneOrMany[*Trip]
+	ActivityObjectPtrVector       = OneOrMany[*ActivityObject]
+	AirObjectPtrVector            = OneOrMany[*AirObject]
+	CarObjectPtrVector            = OneOrMany[*CarObject]
+	CruiseObjectPtrVector         = OneOrMany[*CruiseObject]
+	DirectionsObjectPtrVector     = OneOrMany[*DirectionsObject]
+	LodgingObjectPtrVector        = OneOrMany[*LodgingObject]
+	MapObjectPtrVector            = OneOrMany[*MapObject]
+	NoteObjectPtrVector           = OneOrMany[*NoteObject]
+	RailObjectPtrVector           = OneOrMany[*RailObject]
+	RestaurantObjectPtrVector     = OneOrMany[*RestaurantObject]
+	TransportObjectPtrVector      = OneOrMany[*TransportObject]
+	WeatherObjectVector           = OneOrMany[WeatherObject]
+	PointsProgramVector           = OneOrMany[PointsProgram]
+	ProfileVector                 = OneOrMany[Profile]
+	ImagePtrVector                = OneOrMany[*Image]
+	TravelerPtrVector             = OneOrMany[*Traveler]
+	AirSegmentPtrVector           = OneOrMany[*AirSegment]
+	RailSegmentPtrVector          = OneOrMany[*RailSegment]
+	TransportSegmentPtrVector     = OneOrMany[*TransportSegment]
+	CruiseSegmentPtrVector        = OneOrMany[*CruiseSegment]
+	PointsProgramActivityVector   = OneOrMany[PointsProgramActivity]
+	PointsProgramExpirationVector = OneOrMany[PointsProgramExpiration]
+)
@@ -0,0 +1,68 @@
+package tripit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOneOrManyUnmarshal(t *testing.T) {
+	tests := []struct {
+		in  string
+		ids []string
+	}{
+		{`{"id":"1"}`, []string{"1"}},
+		{`[{"id":"1"},{"id":"2"}]`, []string{"1", "2"}},
+		{`[{"id":"1"},null]`, []string{"1"}},
+		{`[]`, []string{}},
+		{`null`, nil},
+		{`""`, nil},
+	}
+	for _, tt := range tests {
+		var v struct {
+			Trip TripPtrVector
+		}
+		if err := json.Unmarshal([]byte(`{"Trip":`+tt.in+`}`), &v); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if len(v.Trip) != len(tt.ids) {
+			t.Errorf("%s: expected %d trips, got %d", tt.in, len(tt.ids), len(v.Trip))
+			continue
+		}
+		for i, id := range tt.ids {
+			if v.Trip[i].Id != id {
+				t.Errorf("%s: expected trip %s, got %s", tt.in, id, v.Trip[i].Id)
+			}
+		}
+	}
+
+	var w WarningVector
+	if err := json.Unmarshal([]byte(`{"description":"Careful"}`), &w); err != nil || len(w) != 1 || w[0].Description != "Careful" {
+		t.Errorf("Unexpected warnings: %v, %v", w, err)
+	}
+	if err := json.Unmarshal([]byte(`"bad"`), &w); err == nil {
+		t.Error("Expected error for string value")
+	}
+}
+
+func TestOneOrManyMarshal(t *testing.T) {
+	v := OneOrMany[Group]{{DisplayName: "Team", Url: "u"}}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) != `[{"display_name":"Team","url":"u"}]` {
+		t.Errorf("Unexpected JSON: %s, %v", b, err)
+	}
+
+	r := &Request{AirObject: &AirObject{
+		DisplayName: "Trip home",
+		Segment:     AirSegmentPtrVector{{MarketingFlightNumber: "100", StartDateTime: &DateTime{Date: "2011-12-09"}}},
+		Traveler:    TravelerPtrVector{{FirstName: "A"}, {FirstName: "B"}},
+	}}
+	b, err = JsonCodec.Encode(r)
+	if err != nil || string(b) != `{"AirObject":{"display_name":"Trip home","Segment":[{"StartDateTime":{"date":"2011-12-09"},"marketing_flight_number":"100"}],"Traveler":[{"first_name":"A"},{"first_name":"B"}]}}` {
+		t.Errorf("Unexpected JSON: %s, %v", b, err)
+	}
+	b, err = JsonSingleObjectCodec.Encode(r)
+	if err != nil || string(b) != `{"AirObject":{"display_name":"Trip home","Segment":{"StartDateTime":{"date":"2011-12-09"},"marketing_flight_number":"100"},"Traveler":[{"first_name":"A"},{"first_name":"B"}]}}` {
+		t.Errorf("Unexpected JSON: %s, %v", b, err)
+	}
+}